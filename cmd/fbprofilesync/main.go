@@ -0,0 +1,61 @@
+/*
+fbprofilesync takes the Messenger Profile of a Page (get started, greeting, persistent menu,
+ice breakers and whitelisted domains) to what is described in a JSON file, changing only what differs.
+Fields absent from the file are kept, unless -prune is given.
+
+Usage:
+
+	FB_PAGE_ACCESS_TOKEN=... fbprofilesync -config profile.json [-dry-run] [-prune]
+*/
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/novatrixtech/go-fbmessenger/fblib"
+)
+
+func main() {
+	config := flag.String("config", "messenger_profile.json", "JSON file with the desired Messenger Profile")
+	token := flag.String("token", os.Getenv("FB_PAGE_ACCESS_TOKEN"), "Page Access Token (default $FB_PAGE_ACCESS_TOKEN)")
+	appSecret := flag.String("app-secret", os.Getenv("FB_APP_SECRET"), "App Secret used for appsecret_proof (default $FB_APP_SECRET)")
+	apiVersion := flag.String("api-version", fblib.DefaultAPIVersion, "Graph API version")
+	dryRun := flag.Bool("dry-run", false, "print the changes without applying them")
+	prune := flag.Bool("prune", false, "delete the fields absent from the config")
+	timeout := flag.Duration("timeout", time.Minute, "timeout of the whole sync")
+	flag.Parse()
+
+	if *token == "" {
+		fmt.Fprintln(os.Stderr, "fbprofilesync: the Page Access Token is required (-token or FB_PAGE_ACCESS_TOKEN)")
+		os.Exit(2)
+	}
+
+	desired, err := fblib.LoadMessengerProfile(*config)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "fbprofilesync:", err)
+		os.Exit(1)
+	}
+
+	client := fblib.NewClient(*token,
+		fblib.WithAppSecret(*appSecret),
+		fblib.WithAPIVersion(*apiVersion),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	diff, err := client.SyncMessengerProfile(ctx, desired, *dryRun, *prune)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "fbprofilesync:", err)
+		os.Exit(1)
+	}
+
+	fmt.Print(diff.String())
+	if *dryRun && !diff.Empty() {
+		fmt.Println("Dry run: nothing was changed")
+	}
+}
@@ -0,0 +1,185 @@
+package fblib
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/novatrixtech/go-fbmessenger/fbmodelsend"
+)
+
+/*
+SendAirlineBoardingPassMessage - Sends boarding passes to a recipient on Facebook Messenger
+*/
+func (c *Client) SendAirlineBoardingPassMessage(ctx context.Context, boardingPass *fbmodelsend.AirlineBoardingPassPayload, recipient string, msgType int, opts ...SendOption) (resp *fbmodelsend.SendResponse, err error) {
+	err = validateAirlineBoardingPass(boardingPass)
+	if err != nil {
+		return
+	}
+	boardingPass.TemplateType = fbmodelsend.TemplateTypeAirlineBoardingPass
+	return c.sendTemplate(ctx, boardingPass, recipient, msgType, opts...)
+}
+
+/*
+SendAirlineCheckinMessage - Sends a check-in reminder to a recipient on Facebook Messenger
+*/
+func (c *Client) SendAirlineCheckinMessage(ctx context.Context, checkin *fbmodelsend.AirlineCheckinPayload, recipient string, msgType int, opts ...SendOption) (resp *fbmodelsend.SendResponse, err error) {
+	err = validateAirlineCheckin(checkin)
+	if err != nil {
+		return
+	}
+	checkin.TemplateType = fbmodelsend.TemplateTypeAirlineCheckin
+	return c.sendTemplate(ctx, checkin, recipient, msgType, opts...)
+}
+
+/*
+SendAirlineItineraryMessage - Sends a flight itinerary to a recipient on Facebook Messenger
+*/
+func (c *Client) SendAirlineItineraryMessage(ctx context.Context, itinerary *fbmodelsend.AirlineItineraryPayload, recipient string, msgType int, opts ...SendOption) (resp *fbmodelsend.SendResponse, err error) {
+	err = validateAirlineItinerary(itinerary)
+	if err != nil {
+		return
+	}
+	itinerary.TemplateType = fbmodelsend.TemplateTypeAirlineItinerary
+	return c.sendTemplate(ctx, itinerary, recipient, msgType, opts...)
+}
+
+/*
+SendAirlineUpdateMessage - Sends a flight update (delay, gate change or cancellation) to a recipient on Facebook Messenger
+*/
+func (c *Client) SendAirlineUpdateMessage(ctx context.Context, update *fbmodelsend.AirlineUpdatePayload, recipient string, msgType int, opts ...SendOption) (resp *fbmodelsend.SendResponse, err error) {
+	err = validateAirlineUpdate(update)
+	if err != nil {
+		return
+	}
+	update.TemplateType = fbmodelsend.TemplateTypeAirlineUpdate
+	return c.sendTemplate(ctx, update, recipient, msgType, opts...)
+}
+
+//validateAirlineBoardingPass checks the required fields of an Airline Boarding Pass Template
+func validateAirlineBoardingPass(payload *fbmodelsend.AirlineBoardingPassPayload) error {
+	if payload == nil {
+		return errors.New("[SendAirlineBoardingPassMessage] Boarding pass is required")
+	}
+	fields := new(requiredFields)
+	fields.require(payload.IntroMessage, "intro_message")
+	fields.require(payload.Locale, "locale")
+	fields.requireSet(len(payload.BoardingPass) > 0, "boarding_pass")
+	for i, pass := range payload.BoardingPass {
+		name := fmt.Sprintf("boarding_pass[%d]", i)
+		if !fields.requireEntry(pass == nil, name) {
+			continue
+		}
+		fields.require(pass.PassengerName, name+".passenger_name")
+		fields.require(pass.PNRNumber, name+".pnr_number")
+		fields.require(pass.LogoImageURL, name+".logo_image_url")
+		fields.require(pass.AboveBarcodeImageURL, name+".above_bar_code_image_url")
+		fields.requireSet(pass.QRCode != "" || pass.BarcodeImageURL != "", name+".qr_code or "+name+".barcode_image_url")
+		validateFlightInfo(fields, pass.FlightInfo, name+".flight_info")
+	}
+	return fields.err("SendAirlineBoardingPassMessage", "Boarding pass")
+}
+
+//validateAirlineCheckin checks the required fields of an Airline Check-in Template
+func validateAirlineCheckin(payload *fbmodelsend.AirlineCheckinPayload) error {
+	if payload == nil {
+		return errors.New("[SendAirlineCheckinMessage] Check-in is required")
+	}
+	fields := new(requiredFields)
+	fields.require(payload.IntroMessage, "intro_message")
+	fields.require(payload.Locale, "locale")
+	fields.require(payload.CheckinURL, "checkin_url")
+	fields.requireSet(len(payload.FlightInfo) > 0, "flight_info")
+	for i, flight := range payload.FlightInfo {
+		name := fmt.Sprintf("flight_info[%d]", i)
+		if fields.requireEntry(flight == nil, name) {
+			validateFlightInfo(fields, flight, name)
+		}
+	}
+	return fields.err("SendAirlineCheckinMessage", "Check-in")
+}
+
+//validateAirlineItinerary checks the required fields of an Airline Itinerary Template
+func validateAirlineItinerary(payload *fbmodelsend.AirlineItineraryPayload) error {
+	if payload == nil {
+		return errors.New("[SendAirlineItineraryMessage] Itinerary is required")
+	}
+	fields := new(requiredFields)
+	fields.require(payload.IntroMessage, "intro_message")
+	fields.require(payload.Locale, "locale")
+	fields.require(payload.PNRNumber, "pnr_number")
+	fields.require(payload.TotalPrice, "total_price")
+	fields.require(payload.Currency, "currency")
+	fields.requireSet(len(payload.PassengerInfo) > 0, "passenger_info")
+	fields.requireSet(len(payload.FlightInfo) > 0, "flight_info")
+	fields.requireSet(len(payload.PassengerSegmentInfo) > 0, "passenger_segment_info")
+	for i, passenger := range payload.PassengerInfo {
+		name := fmt.Sprintf("passenger_info[%d]", i)
+		if !fields.requireEntry(passenger == nil, name) {
+			continue
+		}
+		fields.require(passenger.PassengerID, name+".passenger_id")
+		fields.require(passenger.Name, name+".name")
+	}
+	for i, flight := range payload.FlightInfo {
+		name := fmt.Sprintf("flight_info[%d]", i)
+		if !fields.requireEntry(flight == nil, name) {
+			continue
+		}
+		fields.require(flight.ConnectionID, name+".connection_id")
+		fields.require(flight.SegmentID, name+".segment_id")
+		fields.require(flight.TravelClass, name+".travel_class")
+		validateFlightInfo(fields, flight, name)
+	}
+	for i, segment := range payload.PassengerSegmentInfo {
+		name := fmt.Sprintf("passenger_segment_info[%d]", i)
+		if !fields.requireEntry(segment == nil, name) {
+			continue
+		}
+		fields.require(segment.SegmentID, name+".segment_id")
+		fields.require(segment.PassengerID, name+".passenger_id")
+		fields.require(segment.Seat, name+".seat")
+		fields.require(segment.SeatType, name+".seat_type")
+	}
+	return fields.err("SendAirlineItineraryMessage", "Itinerary")
+}
+
+//validateAirlineUpdate checks the required fields of an Airline Flight Update Template
+func validateAirlineUpdate(payload *fbmodelsend.AirlineUpdatePayload) error {
+	if payload == nil {
+		return errors.New("[SendAirlineUpdateMessage] Flight update is required")
+	}
+	switch payload.UpdateType {
+	case fbmodelsend.AirlineUpdateDelay, fbmodelsend.AirlineUpdateGateChange, fbmodelsend.AirlineUpdateCancellation:
+	default:
+		return fmt.Errorf("[SendAirlineUpdateMessage] Invalid update type [%s]", payload.UpdateType)
+	}
+	fields := new(requiredFields)
+	fields.require(payload.Locale, "locale")
+	validateFlightInfo(fields, payload.UpdateFlightInfo, "update_flight_info")
+	return fields.err("SendAirlineUpdateMessage", "Flight update")
+}
+
+//validateFlightInfo checks the fields every airline template requires from a flight
+func validateFlightInfo(fields *requiredFields, flight *fbmodelsend.AirlineFlightInfo, name string) {
+	if flight == nil {
+		fields.requireSet(false, name)
+		return
+	}
+	fields.require(flight.FlightNumber, name+".flight_number")
+	fields.requireSet(flight.DepartureAirport != nil, name+".departure_airport")
+	fields.requireSet(flight.ArrivalAirport != nil, name+".arrival_airport")
+	if flight.DepartureAirport != nil {
+		fields.require(flight.DepartureAirport.AirportCode, name+".departure_airport.airport_code")
+		fields.require(flight.DepartureAirport.City, name+".departure_airport.city")
+	}
+	if flight.ArrivalAirport != nil {
+		fields.require(flight.ArrivalAirport.AirportCode, name+".arrival_airport.airport_code")
+		fields.require(flight.ArrivalAirport.City, name+".arrival_airport.city")
+	}
+	if flight.FlightSchedule == nil {
+		fields.requireSet(false, name+".flight_schedule")
+		return
+	}
+	fields.require(flight.FlightSchedule.DepartureTime, name+".flight_schedule.departure_time")
+}
@@ -0,0 +1,61 @@
+package fblib
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/novatrixtech/go-fbmessenger/fbmodelsend"
+)
+
+func TestValidateAirlineNilEntries(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want []string
+	}{
+		{
+			"boarding pass",
+			validateAirlineBoardingPass(&fbmodelsend.AirlineBoardingPassPayload{
+				IntroMessage: "Your boarding pass",
+				Locale:       "en_US",
+				BoardingPass: []*fbmodelsend.BoardingPass{nil},
+			}),
+			[]string{"boarding_pass[0]"},
+		},
+		{
+			"check-in",
+			validateAirlineCheckin(&fbmodelsend.AirlineCheckinPayload{
+				IntroMessage: "Check-in is open",
+				Locale:       "en_US",
+				CheckinURL:   "https://example.com/checkin",
+				FlightInfo:   []*fbmodelsend.AirlineFlightInfo{nil},
+			}),
+			[]string{"flight_info[0]"},
+		},
+		{
+			"itinerary",
+			validateAirlineItinerary(&fbmodelsend.AirlineItineraryPayload{
+				IntroMessage:         "Your itinerary",
+				Locale:               "en_US",
+				PNRNumber:            "ABC123",
+				TotalPrice:           "100",
+				Currency:             "USD",
+				PassengerInfo:        []*fbmodelsend.AirlinePassengerInfo{nil},
+				FlightInfo:           []*fbmodelsend.AirlineFlightInfo{nil},
+				PassengerSegmentInfo: []*fbmodelsend.AirlinePassengerSegmentInfo{nil},
+			}),
+			[]string{"passenger_info[0]", "flight_info[0]", "passenger_segment_info[0]"},
+		},
+	}
+	for _, tt := range tests {
+		if tt.err == nil {
+			t.Errorf("%s: error = nil, want nil entries reported", tt.name)
+			continue
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(tt.err.Error(), want) {
+				t.Errorf("%s: error = %q, want it to name %s", tt.name, tt.err, want)
+			}
+		}
+	}
+}
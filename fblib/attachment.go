@@ -0,0 +1,132 @@
+package fblib
+
+import (
+	"context"
+	"io"
+
+	"github.com/novatrixtech/go-fbmessenger/fbmodelsend"
+)
+
+/*
+SendImageMessage - Sends image message to a recipient on Facebook Messenger
+*/
+func (c *Client) SendImageMessage(ctx context.Context, url string, recipient string, msgType int, opts ...SendOption) (*fbmodelsend.SendResponse, error) {
+	return c.sendAttachmentURL(ctx, fbmodelsend.AttachmentTypeImage, url, recipient, msgType, opts...)
+}
+
+/*
+SendImageAttachment - Sends an image uploaded before, identified by its attachment ID, to a recipient on Facebook Messenger
+*/
+func (c *Client) SendImageAttachment(ctx context.Context, attachmentID string, recipient string, msgType int, opts ...SendOption) (*fbmodelsend.SendResponse, error) {
+	return c.SendAttachment(ctx, fbmodelsend.AttachmentTypeImage, attachmentID, recipient, msgType, opts...)
+}
+
+/*
+SendImageUpload - Uploads an image along with the message to a recipient on Facebook Messenger
+*/
+func (c *Client) SendImageUpload(ctx context.Context, fileName string, mimeType string, file io.Reader, recipient string, msgType int, opts ...SendOption) (*fbmodelsend.SendResponse, error) {
+	return c.SendAttachmentFile(ctx, fbmodelsend.AttachmentTypeImage, fileName, mimeType, file, recipient, msgType, opts...)
+}
+
+/*
+SendAudioMessage - Sends audio message to a recipient on Facebook Messenger
+*/
+func (c *Client) SendAudioMessage(ctx context.Context, url string, recipient string, msgType int, opts ...SendOption) (*fbmodelsend.SendResponse, error) {
+	return c.sendAttachmentURL(ctx, fbmodelsend.AttachmentTypeAudio, url, recipient, msgType, opts...)
+}
+
+/*
+SendAudioAttachment - Sends an audio uploaded before, identified by its attachment ID, to a recipient on Facebook Messenger
+*/
+func (c *Client) SendAudioAttachment(ctx context.Context, attachmentID string, recipient string, msgType int, opts ...SendOption) (*fbmodelsend.SendResponse, error) {
+	return c.SendAttachment(ctx, fbmodelsend.AttachmentTypeAudio, attachmentID, recipient, msgType, opts...)
+}
+
+/*
+SendAudioUpload - Uploads an audio, e.g. a voice note, along with the message to a recipient on Facebook Messenger
+*/
+func (c *Client) SendAudioUpload(ctx context.Context, fileName string, mimeType string, file io.Reader, recipient string, msgType int, opts ...SendOption) (*fbmodelsend.SendResponse, error) {
+	return c.SendAttachmentFile(ctx, fbmodelsend.AttachmentTypeAudio, fileName, mimeType, file, recipient, msgType, opts...)
+}
+
+/*
+SendVideoMessage - Sends video message to a recipient on Facebook Messenger
+*/
+func (c *Client) SendVideoMessage(ctx context.Context, url string, recipient string, msgType int, opts ...SendOption) (*fbmodelsend.SendResponse, error) {
+	return c.sendAttachmentURL(ctx, fbmodelsend.AttachmentTypeVideo, url, recipient, msgType, opts...)
+}
+
+/*
+SendVideoAttachment - Sends a video uploaded before, identified by its attachment ID, to a recipient on Facebook Messenger
+*/
+func (c *Client) SendVideoAttachment(ctx context.Context, attachmentID string, recipient string, msgType int, opts ...SendOption) (*fbmodelsend.SendResponse, error) {
+	return c.SendAttachment(ctx, fbmodelsend.AttachmentTypeVideo, attachmentID, recipient, msgType, opts...)
+}
+
+/*
+SendVideoUpload - Uploads a video along with the message to a recipient on Facebook Messenger
+*/
+func (c *Client) SendVideoUpload(ctx context.Context, fileName string, mimeType string, file io.Reader, recipient string, msgType int, opts ...SendOption) (*fbmodelsend.SendResponse, error) {
+	return c.SendAttachmentFile(ctx, fbmodelsend.AttachmentTypeVideo, fileName, mimeType, file, recipient, msgType, opts...)
+}
+
+/*
+SendFileMessage - Sends a file (PDF, invoice, spreadsheet...) to a recipient on Facebook Messenger
+*/
+func (c *Client) SendFileMessage(ctx context.Context, url string, recipient string, msgType int, opts ...SendOption) (*fbmodelsend.SendResponse, error) {
+	return c.sendAttachmentURL(ctx, fbmodelsend.AttachmentTypeFile, url, recipient, msgType, opts...)
+}
+
+/*
+SendFileAttachment - Sends a file uploaded before, identified by its attachment ID, to a recipient on Facebook Messenger
+*/
+func (c *Client) SendFileAttachment(ctx context.Context, attachmentID string, recipient string, msgType int, opts ...SendOption) (*fbmodelsend.SendResponse, error) {
+	return c.SendAttachment(ctx, fbmodelsend.AttachmentTypeFile, attachmentID, recipient, msgType, opts...)
+}
+
+/*
+SendFileUpload - Uploads a file along with the message to a recipient on Facebook Messenger
+*/
+func (c *Client) SendFileUpload(ctx context.Context, fileName string, mimeType string, file io.Reader, recipient string, msgType int, opts ...SendOption) (*fbmodelsend.SendResponse, error) {
+	return c.SendAttachmentFile(ctx, fbmodelsend.AttachmentTypeFile, fileName, mimeType, file, recipient, msgType, opts...)
+}
+
+/*
+SendAttachment - Sends an attachment previously uploaded, identified by its attachment ID, to a recipient on Facebook Messenger
+*/
+func (c *Client) SendAttachment(ctx context.Context, attachmentType string, attachmentID string, recipient string, msgType int, opts ...SendOption) (*fbmodelsend.SendResponse, error) {
+	attch := new(fbmodelsend.Attachment)
+	attch.AttachmentType = attachmentType
+	attch.Payload.AttachmentID = attachmentID
+	return c.sendAttachment(ctx, attch, recipient, msgType, opts...)
+}
+
+/*
+SendAttachmentFile - Sends file as an attachment to a recipient on Facebook Messenger.
+file is streamed to Facebook as multipart/form-data along with the message, so it's never fully held in memory.
+*/
+func (c *Client) SendAttachmentFile(ctx context.Context, attachmentType string, fileName string, mimeType string, file io.Reader, recipient string, msgType int, opts ...SendOption) (*fbmodelsend.SendResponse, error) {
+	attch := new(fbmodelsend.Attachment)
+	attch.AttachmentType = attachmentType
+	attch.File = &fbmodelsend.AttachmentFile{Name: fileName, MIMEType: mimeType, Content: file}
+	return c.sendAttachment(ctx, attch, recipient, msgType, opts...)
+}
+
+//sendAttachmentURL sends the asset at url, fetched by Facebook, as an attachment
+func (c *Client) sendAttachmentURL(ctx context.Context, attachmentType string, url string, recipient string, msgType int, opts ...SendOption) (*fbmodelsend.SendResponse, error) {
+	attch := new(fbmodelsend.Attachment)
+	attch.AttachmentType = attachmentType
+	attch.Payload.URL = url
+	return c.sendAttachment(ctx, attch, recipient, msgType, opts...)
+}
+
+/*
+sendAttachment - Sends a message with a single attachment to a recipient on Facebook Messenger
+*/
+func (c *Client) sendAttachment(ctx context.Context, attch *fbmodelsend.Attachment, recipient string, msgType int, opts ...SendOption) (*fbmodelsend.SendResponse, error) {
+	message := new(fbmodelsend.Letter)
+	message.MessageType = defineMessageType(msgType)
+	message.Message.Attachment = attch
+	message.Recipient.ID = recipient
+	return c.sendMessage(ctx, recipient, message, opts...)
+}
@@ -0,0 +1,295 @@
+package fblib
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/davecgh/go-spew/spew"
+
+	"github.com/novatrixtech/go-fbmessenger/fbmodelsend"
+)
+
+//DefaultBaseURL is the Graph API host used when no other base URL is configured
+const DefaultBaseURL = "https://graph.facebook.com"
+
+//DefaultAPIVersion is the Graph API version used when no other version is configured
+const DefaultAPIVersion = "v6.0"
+
+//messagesPath is the Send API endpoint relative to the Graph API version
+const messagesPath = "me/messages"
+
+/*
+Client - Facebook Messenger client bound to a Page.
+It is created once and reused for every call made to the Graph API on behalf of the Page.
+Every call receives a context.Context that carries its deadline, cancellation and request-scoped values to the HTTP request.
+A Client is safe for concurrent use.
+*/
+type Client struct {
+	accessToken string
+	appSecret   string
+	apiVersion  string
+	baseURL     string
+	httpClient  *http.Client
+	retryPolicy RetryPolicy
+
+	pageLimiter      *RateLimiter
+	recipientLimiter *RateLimiter
+
+	//messagesURL overrides the Send API URL. It keeps the legacy behaviour of passing an URL as access token.
+	messagesURL string
+}
+
+/*
+ClientOption configures a Client during its creation
+*/
+type ClientOption func(*Client)
+
+/*
+WithAppSecret sets the App Secret. When it is set every Graph API call carries the appsecret_proof parameter.
+*/
+func WithAppSecret(appSecret string) ClientOption {
+	return func(c *Client) {
+		c.appSecret = appSecret
+	}
+}
+
+/*
+WithAPIVersion sets the Graph API version, e.g. v6.0
+*/
+func WithAPIVersion(version string) ClientOption {
+	return func(c *Client) {
+		c.apiVersion = version
+	}
+}
+
+/*
+WithBaseURL sets the Graph API base URL. Useful for proxies and tests.
+*/
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) {
+		c.baseURL = baseURL
+	}
+}
+
+/*
+WithHTTPClient sets the http.Client used to call the Graph API
+*/
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+/*
+NewClient creates a Client for the Page identified by its Page Access Token.
+Unless WithHTTPClient is given, calls time out after 30 seconds even when the context has no deadline.
+*/
+func NewClient(accessToken string, opts ...ClientOption) *Client {
+	c := &Client{
+		accessToken: accessToken,
+		apiVersion:  DefaultAPIVersion,
+		baseURL:     DefaultBaseURL,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.httpClient == nil {
+		c.httpClient = &http.Client{
+			Timeout: time.Second * 30,
+		}
+	}
+	c.baseURL = strings.TrimRight(c.baseURL, "/")
+	return c
+}
+
+//legacyClient returns the Client used by the package-level functions that receive the access token on every call
+func legacyClient(accessToken string) *Client {
+	c := NewClient(accessToken)
+	if strings.Contains(accessToken, "http") {
+		c.messagesURL = accessToken
+	}
+	return c
+}
+
+//appSecretProof returns the appsecret_proof of the access token signed with App Secret
+func (c *Client) appSecretProof() string {
+	mac := hmac.New(sha256.New, []byte(c.appSecret))
+	mac.Write([]byte(c.accessToken))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+//graphURL builds the URL of a Graph API path adding the credentials to the query string
+func (c *Client) graphURL(path string, query url.Values) string {
+	if path == messagesPath && c.messagesURL != "" {
+		return c.messagesURL
+	}
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("access_token", c.accessToken)
+	if c.appSecret != "" {
+		query.Set("appsecret_proof", c.appSecretProof())
+	}
+	return fmt.Sprintf("%s/%s/%s?%s", c.baseURL, c.apiVersion, strings.TrimLeft(path, "/"), query.Encode())
+}
+
+/*
+sendMessage - Sends a generic message to Facebook Messenger, after applying the send options, and returns the Send API response
+*/
+func (c *Client) sendMessage(ctx context.Context, recipient string, message interface{}, opts ...SendOption) (*fbmodelsend.SendResponse, error) {
+	applySendOptions(message, opts)
+
+	if logLevelDebug {
+		scs := spew.ConfigState{Indent: "\t"}
+		scs.Dump(message)
+		return new(fbmodelsend.SendResponse), nil
+	}
+
+	if c.recipientLimiter != nil {
+		release, err := c.recipientLimiter.acquire(ctx, recipient)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+	return c.postMessage(ctx, message)
+}
+
+/*
+postMessage - Posts message to the Send API, without the recipient rate limiter, and returns the Send API response
+*/
+func (c *Client) postMessage(ctx context.Context, message interface{}) (*fbmodelsend.SendResponse, error) {
+	resp := new(fbmodelsend.SendResponse)
+	if letter, ok := message.(*fbmodelsend.Letter); ok && letter.Message.Attachment != nil && letter.Message.Attachment.File != nil {
+		body, err := letterMultipartBody(letter)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.call(ctx, http.MethodPost, messagesPath, nil, body, false, resp); err != nil {
+			return nil, err
+		}
+		return resp, nil
+	}
+
+	if err := c.doGraphRequest(ctx, http.MethodPost, messagesPath, nil, message, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+/*
+requestBody creates the body, and its content type, of one attempt of a Graph API call
+*/
+type requestBody func() (body io.Reader, contentType string, err error)
+
+/*
+doGraphRequest calls the Graph API sending body as JSON (when not nil) and decoding the response into out (when not nil)
+*/
+func (c *Client) doGraphRequest(ctx context.Context, method string, path string, query url.Values, body interface{}, out interface{}) error {
+	var newBody requestBody
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		newBody = func() (io.Reader, string, error) {
+			return bytes.NewReader(data), "application/json", nil
+		}
+	}
+	return c.call(ctx, method, path, query, newBody, true, out)
+}
+
+/*
+call makes a Graph API call decoding the response into out (when not nil).
+Only replayable bodies, those that can be created again for every attempt, are retried.
+*/
+func (c *Client) call(ctx context.Context, method string, path string, query url.Values, newBody requestBody, replayable bool, out interface{}) error {
+	fbURL := c.graphURL(path, query)
+
+	var bodyFromFb []byte
+	attempt := func() (err error) {
+		if c.pageLimiter != nil {
+			if err = c.pageLimiter.Wait(ctx, c.accessToken); err != nil {
+				return
+			}
+		}
+		bodyFromFb, err = c.doHTTP(ctx, method, fbURL, newBody)
+		return
+	}
+
+	var err error
+	if replayable {
+		err = c.withRetry(ctx, attempt)
+	} else {
+		err = attempt()
+	}
+	if err != nil {
+		return err
+	}
+
+	if out != nil {
+		if err := json.Unmarshal(bodyFromFb, out); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+/*
+doHTTP makes a single HTTP call to the Graph API and returns the response body of successful calls
+*/
+func (c *Client) doHTTP(ctx context.Context, method string, fbURL string, newBody requestBody) ([]byte, error) {
+	var body io.Reader
+	var contentType string
+	if newBody != nil {
+		var err error
+		body, contentType, err = newBody()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	reqFb, err := http.NewRequestWithContext(ctx, method, fbURL, body)
+	if err != nil {
+		if closer, ok := body.(io.Closer); ok {
+			closer.Close()
+		}
+		return nil, redactURLError(err)
+	}
+	if contentType != "" {
+		reqFb.Header.Set("Content-Type", contentType)
+	}
+	reqFb.Header.Set("Connection", "close")
+	reqFb.Close = true
+
+	respFb, err := c.httpClient.Do(reqFb)
+	if err != nil {
+		return nil, redactURLError(err)
+	}
+	defer respFb.Body.Close()
+
+	bodyFromFb, err := ioutil.ReadAll(respFb.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if respFb.StatusCode < 200 || respFb.StatusCode >= 300 {
+		graphErr := newGraphError(respFb.StatusCode, bodyFromFb)
+		graphErr.RetryAfter = retryAfter(respFb.Header)
+		return nil, graphErr
+	}
+
+	return bodyFromFb, nil
+}
@@ -0,0 +1,106 @@
+package fblib
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+//ErrInvalidCallToFacebook is specific error when Facebook Messenger returns error after being called.
+//Every *GraphError matches it through errors.Is.
+var ErrInvalidCallToFacebook = errors.New("fblib: invalid call to Facebook")
+
+/*
+GraphError - Represents an error returned by the Graph API.
+E.g.
+{"error":{"message":"(#100) No matching user found","type":"OAuthException","code":100,"error_subcode":2018001,"fbtrace_id":"AbCdEfGh"}}
+*/
+type GraphError struct {
+	StatusCode   int    `json:"-"`
+	Message      string `json:"message"`
+	Type         string `json:"type"`
+	Code         int    `json:"code"`
+	ErrorSubcode int    `json:"error_subcode"`
+	FBTraceID    string `json:"fbtrace_id"`
+	IsTransient  bool   `json:"is_transient"`
+	//RetryAfter is the wait asked by Facebook through the Retry-After header
+	RetryAfter time.Duration `json:"-"`
+	//Body is the raw response body when Facebook didn't return the error object
+	Body string `json:"-"`
+}
+
+//Error implements error interface
+func (e *GraphError) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = e.Body
+	}
+	return fmt.Sprintf("[fblib] Graph API error: status [%d] code [%d] subcode [%d] type [%s] fbtrace_id [%s]: %s",
+		e.StatusCode,
+		e.Code,
+		e.ErrorSubcode,
+		e.Type,
+		e.FBTraceID,
+		msg,
+	)
+}
+
+//Is makes errors.Is(err, ErrInvalidCallToFacebook) true for every GraphError
+func (e *GraphError) Is(target error) bool {
+	return target == ErrInvalidCallToFacebook
+}
+
+//IsRateLimited tells whether the call was throttled by Facebook (application, user or page level)
+func (e *GraphError) IsRateLimited() bool {
+	switch e.Code {
+	case 4, 17, 32, 613:
+		return true
+	}
+	return false
+}
+
+//IsUserBlockedPage tells whether the user is not available to receive messages from the Page anymore
+func (e *GraphError) IsUserBlockedPage() bool {
+	return e.Code == 551 || e.ErrorSubcode == 1545041
+}
+
+//IsOutsideMessagingWindow tells whether the message was sent after the standard messaging window had been closed
+func (e *GraphError) IsOutsideMessagingWindow() bool {
+	return e.Code == 10 && e.ErrorSubcode == 2018278
+}
+
+//IsTokenExpired tells whether the Page Access Token is expired or was invalidated
+func (e *GraphError) IsTokenExpired() bool {
+	return e.Code == 190
+}
+
+//IsInvalidRecipient tells whether the recipient doesn't exist or can't be reached by the Page
+func (e *GraphError) IsInvalidRecipient() bool {
+	return e.Code == 100 && e.ErrorSubcode == 2018001
+}
+
+//newGraphError parses the error object from a Graph API response body
+func newGraphError(statusCode int, body []byte) *GraphError {
+	var envelope struct {
+		Error *GraphError `json:"error"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil || envelope.Error == nil {
+		return &GraphError{StatusCode: statusCode, Body: string(body)}
+	}
+	envelope.Error.StatusCode = statusCode
+	return envelope.Error
+}
+
+//redactURLError removes the query string, where the access token is, from the URL of errors returned by http.Client
+func redactURLError(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		if i := strings.Index(urlErr.URL, "?"); i >= 0 {
+			urlErr.URL = urlErr.URL[:i]
+		}
+	}
+	return err
+}
@@ -0,0 +1,79 @@
+package fblib
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/novatrixtech/go-fbmessenger/fbmodelsend"
+)
+
+/*
+PassThreadControl - Passes the control of the conversation with recipient to the app targetAppID, e.g. fbmodelsend.PageInboxAppID.
+metadata is delivered to the target app in the pass_thread_control event.
+*/
+func (c *Client) PassThreadControl(ctx context.Context, recipient string, targetAppID int64, metadata string) error {
+	return c.threadControl(ctx, "me/pass_thread_control", recipient, targetAppID, metadata)
+}
+
+/*
+TakeThreadControl - Takes, as Primary Receiver, the control of the conversation with recipient from the app that has it
+*/
+func (c *Client) TakeThreadControl(ctx context.Context, recipient string, metadata string) error {
+	return c.threadControl(ctx, "me/take_thread_control", recipient, 0, metadata)
+}
+
+/*
+RequestThreadControl - Asks, as Secondary Receiver, the Primary Receiver for the control of the conversation with recipient
+*/
+func (c *Client) RequestThreadControl(ctx context.Context, recipient string, metadata string) error {
+	return c.threadControl(ctx, "me/request_thread_control", recipient, 0, metadata)
+}
+
+/*
+ReleaseThreadControl - Gives the control of the conversation with recipient back to the Primary Receiver
+*/
+func (c *Client) ReleaseThreadControl(ctx context.Context, recipient string, metadata string) error {
+	return c.threadControl(ctx, "me/release_thread_control", recipient, 0, metadata)
+}
+
+/*
+GetThreadOwner - Gets the app ID that controls the conversation with recipient
+*/
+func (c *Client) GetThreadOwner(ctx context.Context, recipient string) (appID string, err error) {
+	query := url.Values{}
+	query.Set("recipient", recipient)
+
+	var resp struct {
+		Data []struct {
+			ThreadOwner fbmodelsend.ThreadOwner `json:"thread_owner"`
+		} `json:"data"`
+	}
+	if err = c.doGraphRequest(ctx, http.MethodGet, "me/thread_owner", query, nil, &resp); err != nil {
+		return "", err
+	}
+	if len(resp.Data) == 0 {
+		return "", errors.New("[GetThreadOwner] Facebook didn't return the thread owner")
+	}
+	return resp.Data[0].ThreadOwner.AppID, nil
+}
+
+//threadControl calls a Handover Protocol endpoint
+func (c *Client) threadControl(ctx context.Context, path string, recipient string, targetAppID int64, metadata string) error {
+	control := new(fbmodelsend.ThreadControl)
+	control.Recipient.ID = recipient
+	control.TargetAppID = targetAppID
+	control.Metadata = metadata
+
+	var resp struct {
+		Success bool `json:"success"`
+	}
+	if err := c.doGraphRequest(ctx, http.MethodPost, path, nil, control, &resp); err != nil {
+		return err
+	}
+	if !resp.Success {
+		return errors.New("[threadControl] Facebook didn't confirm the call to " + path)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package fblib
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/novatrixtech/go-fbmessenger/fbmodelsend"
+)
+
+func TestGetThreadOwner(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    string
+		wantErr bool
+	}{
+		{"owner", `{"data":[{"thread_owner":{"app_id":"263902037430900"}}]}`, "263902037430900", false},
+		{"no data", `{"data":[]}`, "", true},
+	}
+	for _, tt := range tests {
+		var recipient string
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet || !strings.HasSuffix(r.URL.Path, "/me/thread_owner") {
+				t.Errorf("%s: request = %s %s, want GET me/thread_owner", tt.name, r.Method, r.URL.Path)
+			}
+			recipient = r.URL.Query().Get("recipient")
+			w.Write([]byte(tt.body))
+		}))
+		c := NewClient("token", WithBaseURL(srv.URL))
+
+		appID, err := c.GetThreadOwner(context.Background(), "123")
+		srv.Close()
+		if (err != nil) != tt.wantErr || appID != tt.want {
+			t.Errorf("%s: GetThreadOwner() = %q, %v, want %q (error %v)", tt.name, appID, err, tt.want, tt.wantErr)
+		}
+		if recipient != "123" {
+			t.Errorf("%s: recipient = %q, want 123", tt.name, recipient)
+		}
+	}
+}
+
+func TestThreadControl(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr bool
+	}{
+		{"confirmed", `{"success":true}`, false},
+		{"not confirmed", `{"success":false}`, true},
+	}
+	for _, tt := range tests {
+		var path string
+		var control fbmodelsend.ThreadControl
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path = r.URL.Path
+			if err := json.NewDecoder(r.Body).Decode(&control); err != nil {
+				t.Errorf("%s: decoding the body: %v", tt.name, err)
+			}
+			w.Write([]byte(tt.body))
+		}))
+		c := NewClient("token", WithBaseURL(srv.URL))
+
+		err := c.PassThreadControl(context.Background(), "123", 263902037430900, "to the inbox")
+		srv.Close()
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: PassThreadControl() error = %v, want error %v", tt.name, err, tt.wantErr)
+		}
+		if !strings.HasSuffix(path, "/me/pass_thread_control") {
+			t.Errorf("%s: path = %q, want me/pass_thread_control", tt.name, path)
+		}
+		if control.Recipient.ID != "123" || control.TargetAppID != 263902037430900 || control.Metadata != "to the inbox" {
+			t.Errorf("%s: body = %+v, want recipient, target app and metadata", tt.name, control)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package fblib
+
+import (
+	"context"
+	"errors"
+
+	"github.com/novatrixtech/go-fbmessenger/fbmodelsend"
+)
+
+/*
+SendMediaTemplateMessage - Sends an image or video with up to one button to a recipient on Facebook Messenger.
+element must have MediaType and either AttachmentID or URL (a Facebook URL of the image or video).
+More details at https://developers.facebook.com/docs/messenger-platform/send-messages/template/media
+*/
+func (c *Client) SendMediaTemplateMessage(ctx context.Context, element *fbmodelsend.TemplateElement, recipient string, msgType int, opts ...SendOption) (*fbmodelsend.SendResponse, error) {
+	if err := validateMediaElement(element); err != nil {
+		return nil, err
+	}
+
+	msg := new(fbmodelsend.Letter)
+	msg.Recipient.ID = recipient
+	msg.MessageType = defineMessageType(msgType)
+
+	attch := new(fbmodelsend.Attachment)
+	attch.AttachmentType = fbmodelsend.AttachmentTypeTemplate
+	attch.Payload.TemplateType = fbmodelsend.TemplateTypeMedia
+	attch.Payload.Elements = []*fbmodelsend.TemplateElement{element}
+
+	msg.Message.Attachment = attch
+
+	return c.sendMessage(ctx, recipient, msg, opts...)
+}
+
+//validateMediaElement checks the rules of Media Template elements
+func validateMediaElement(element *fbmodelsend.TemplateElement) error {
+	if element == nil {
+		return errors.New("[SendMediaTemplateMessage] Media element is required")
+	}
+	if element.MediaType != fbmodelsend.MediaTypeImage && element.MediaType != fbmodelsend.MediaTypeVideo {
+		return errors.New("[SendMediaTemplateMessage] Media type must be image or video")
+	}
+	if (element.AttachmentID == "") == (element.URL == "") {
+		return errors.New("[SendMediaTemplateMessage] Either attachment ID or URL must be set")
+	}
+	if len(element.Buttons) > 1 {
+		return errors.New("[SendMediaTemplateMessage] Media template supports up to one button")
+	}
+	return validateButtons(element.Buttons)
+}
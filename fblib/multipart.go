@@ -0,0 +1,112 @@
+package fblib
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/textproto"
+	"sort"
+	"strings"
+
+	"github.com/novatrixtech/go-fbmessenger/fbmodelsend"
+)
+
+/*
+formField is a text field of a multipart/form-data body
+*/
+type formField struct {
+	name  string
+	value string
+}
+
+/*
+formFile is the file part of a multipart/form-data body
+*/
+type formFile struct {
+	fieldName string
+	fileName  string
+	mimeType  string
+	content   io.Reader
+}
+
+//quoteEscaper escapes the file name placed in the Content-Disposition header
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+/*
+multipartBody streams fields and file as multipart/form-data without holding the file in memory.
+The body can be read only once, so calls using it are not retried.
+*/
+func multipartBody(fields []formField, file formFile) requestBody {
+	return func() (io.Reader, string, error) {
+		pr, pw := io.Pipe()
+		mw := multipart.NewWriter(pw)
+
+		go func() {
+			pw.CloseWithError(writeMultipart(mw, fields, file))
+		}()
+
+		return pr, mw.FormDataContentType(), nil
+	}
+}
+
+//writeMultipart writes every part of the body and closes the multipart writer
+func writeMultipart(mw *multipart.Writer, fields []formField, file formFile) error {
+	for _, field := range fields {
+		if err := mw.WriteField(field.name, field.value); err != nil {
+			return err
+		}
+	}
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`,
+		quoteEscaper.Replace(file.fieldName),
+		quoteEscaper.Replace(file.fileName)))
+	if file.mimeType != "" {
+		header.Set("Content-Type", file.mimeType)
+	} else {
+		header.Set("Content-Type", "application/octet-stream")
+	}
+	part, err := mw.CreatePart(header)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(part, file.content); err != nil {
+		return err
+	}
+
+	return mw.Close()
+}
+
+/*
+letterMultipartBody sends every top level field of letter as a form field and its attachment file as filedata
+*/
+func letterMultipartBody(letter *fbmodelsend.Letter) (requestBody, error) {
+	data, err := json.Marshal(letter)
+	if err != nil {
+		return nil, err
+	}
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fields := make([]formField, 0, len(names))
+	for _, name := range names {
+		value := string(values[name])
+		var text string
+		if err := json.Unmarshal(values[name], &text); err == nil {
+			value = text
+		}
+		fields = append(fields, formField{name: name, value: value})
+	}
+
+	file := letter.Message.Attachment.File
+	return multipartBody(fields, formFile{fieldName: "filedata", fileName: file.Name, mimeType: file.MIMEType, content: file.Content}), nil
+}
@@ -0,0 +1,115 @@
+package fblib
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSendFileUploadMultipart(t *testing.T) {
+	fields := make(map[string]string)
+	var fileName, fileType, fileContent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Errorf("ParseMultipartForm() error = %v", err)
+			return
+		}
+		for name, values := range r.MultipartForm.Value {
+			fields[name] = values[0]
+		}
+		file, header, err := r.FormFile("filedata")
+		if err != nil {
+			t.Errorf("FormFile(filedata) error = %v", err)
+			return
+		}
+		defer file.Close()
+		content, _ := ioutil.ReadAll(file)
+		fileName, fileType, fileContent = header.Filename, header.Header.Get("Content-Type"), string(content)
+		w.Write([]byte(`{"recipient_id":"123","message_id":"mid.1"}`))
+	}))
+	defer srv.Close()
+	c := NewClient("token", WithBaseURL(srv.URL))
+
+	resp, err := c.SendFileUpload(context.Background(), "invoice.pdf", "application/pdf", strings.NewReader("%PDF-1.4"),
+		"123", MessageTypeResponse, WithPersona("persona.1"))
+	if err != nil {
+		t.Fatalf("SendFileUpload() error = %v", err)
+	}
+	if resp.MessageID != "mid.1" {
+		t.Errorf("MessageID = %q, want mid.1", resp.MessageID)
+	}
+
+	var recipient struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal([]byte(fields["recipient"]), &recipient); err != nil || recipient.ID != "123" {
+		t.Errorf("recipient = %q, want the JSON of recipient 123", fields["recipient"])
+	}
+	var message struct {
+		Attachment struct {
+			Type string `json:"type"`
+		} `json:"attachment"`
+	}
+	if err := json.Unmarshal([]byte(fields["message"]), &message); err != nil || message.Attachment.Type != "file" {
+		t.Errorf("message = %q, want the JSON of a file attachment", fields["message"])
+	}
+	if fields["messaging_type"] != "RESPONSE" {
+		t.Errorf("messaging_type = %q, want RESPONSE", fields["messaging_type"])
+	}
+	if fields["persona_id"] != "persona.1" {
+		t.Errorf("persona_id = %q, want persona.1", fields["persona_id"])
+	}
+	if fileName != "invoice.pdf" || fileType != "application/pdf" || fileContent != "%PDF-1.4" {
+		t.Errorf("filedata = %q (%s) %q, want invoice.pdf (application/pdf) %%PDF-1.4", fileName, fileType, fileContent)
+	}
+}
+
+//endlessFile is file content that never ends
+type endlessFile struct{}
+
+func (endlessFile) Read(p []byte) (int, error) {
+	return len(p), nil
+}
+
+//multipartWriterRunning tells whether a goroutine started by multipartBody is still running
+func multipartWriterRunning() bool {
+	buf := make([]byte, 1<<20)
+	return strings.Contains(string(buf[:runtime.Stack(buf, true)]), "fblib.multipartBody")
+}
+
+func TestSendFileUploadStopsStreamingOnFailure(t *testing.T) {
+	refused := httptest.NewServer(http.NotFoundHandler())
+	refused.Close()
+	rejected := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"message":"Invalid file","code":100}}`))
+	}))
+	defer rejected.Close()
+
+	tests := []struct {
+		name    string
+		baseURL string
+	}{
+		{"connection refused", refused.URL},
+		{"rejected before reading the body", rejected.URL},
+	}
+	for _, tt := range tests {
+		c := NewClient("token", WithBaseURL(tt.baseURL))
+		if _, err := c.SendFileUpload(context.Background(), "big.bin", "", endlessFile{}, "123", MessageTypeResponse); err == nil {
+			t.Errorf("%s: SendFileUpload() error = nil, want error", tt.name)
+		}
+		deadline := time.Now().Add(2 * time.Second)
+		for multipartWriterRunning() && time.Now().Before(deadline) {
+			time.Sleep(10 * time.Millisecond)
+		}
+		if multipartWriterRunning() {
+			t.Errorf("%s: the multipart body is still being written after SendFileUpload() returned", tt.name)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package fblib
 
 import (
 	"errors"
+	"fmt"
 	"log"
 	"strings"
 
@@ -9,10 +10,158 @@ import (
 )
 
 /*
-GenerateButtonElements generates Messenger buttons
+ButtonOption defines a button of GenerateButtonTemplateElements
+*/
+type ButtonOption struct {
+	ButtonType string
+	Payload    string
+	URL        string
+	Title      string
+}
+
+/*
+QuickReplyOption defines a text quick reply of GenerateQuickReplies.
+ImageURL is optional and, when set, Title can be empty.
+*/
+type QuickReplyOption struct {
+	Title    string
+	Payload  string
+	ImageURL string
+}
+
+/*
+GenerateButtonTemplateElements generates a template element with a button for each option.
+Every button is validated and the first invalid one is returned as error.
+*/
+func GenerateButtonTemplateElements(title string, subtitle string, imgURL string, options []ButtonOption) ([]*fbmodelsend.TemplateElement, error) {
+	var btn []*fbmodelsend.Button
+	for i, opt := range options {
+		elem := buttonFromOption(opt)
+		if err := elem.Validate(); err != nil {
+			return nil, fmt.Errorf("[GenerateButtonTemplateElements] Option %d: %s", i, err.Error())
+		}
+		btn = append(btn, elem)
+	}
+	return []*fbmodelsend.TemplateElement{newButtonTemplateElement(title, subtitle, imgURL, btn)}, nil
+}
+
+/*
+GenerateQuickReplies generates text quick replies
+*/
+func GenerateQuickReplies(options []QuickReplyOption) ([]*fbmodelsend.QuickReply, error) {
+	if len(options) < 1 {
+		return nil, errors.New("[GenerateQuickReplies] It's necessary send at least one option")
+	}
+	var qrf []*fbmodelsend.QuickReply
+	for i, opt := range options {
+		if (opt.Title == "" && opt.ImageURL == "") || opt.Payload == "" {
+			return nil, fmt.Errorf("[GenerateQuickReplies] Option %d without title or payload", i)
+		}
+		qrf = append(qrf, fbmodelsend.NewImageQuickReply(opt.Title, opt.Payload, opt.ImageURL))
+	}
+	return qrf, nil
+}
+
+/*
+ParseButtonOption parses the legacy format buttonType#payload#url#buttontext.
+A # that is part of a value is written as \# and a backslash as \\.
+Unescaped # beyond the fourth field are kept in the URL of web_url and account_link buttons,
+so URL fragments survive, and in the button text of the other buttons.
+*/
+func ParseButtonOption(option string) (ButtonOption, error) {
+	tmp := splitOption(option, 0)
+	if len(tmp) < 4 {
+		return ButtonOption{}, fmt.Errorf("[ParseButtonOption] Button with invalid item number: [%s]", option)
+	}
+	opt := ButtonOption{
+		ButtonType: tmp[0],
+		Payload:    tmp[1],
+	}
+	switch opt.ButtonType {
+	case fbmodelsend.ButtonTypeWebURL, fbmodelsend.ButtonTypeAccountLink:
+		last := len(tmp) - 1
+		opt.URL = strings.Join(tmp[2:last], "#")
+		opt.Title = tmp[last]
+	default:
+		opt.URL = tmp[2]
+		opt.Title = strings.Join(tmp[3:], "#")
+	}
+	return opt, nil
+}
+
+/*
+ParseQuickReplyOption parses the legacy format title#payload, or title#payload#imageURL for image quick replies.
+A # that is part of a value is written as \# and a backslash as \\.
+Unescaped # beyond the second field are kept in the payload unless the third field is an http(s) URL.
+*/
+func ParseQuickReplyOption(option string) (QuickReplyOption, error) {
+	tmp := splitOption(option, 3)
+	if len(tmp) < 2 {
+		return QuickReplyOption{}, fmt.Errorf("[ParseQuickReplyOption] Quick reply without payload: [%s]", option)
+	}
+	opt := QuickReplyOption{
+		Title:   tmp[0],
+		Payload: tmp[1],
+	}
+	if len(tmp) == 3 {
+		if isHTTPURL(tmp[2]) {
+			opt.ImageURL = tmp[2]
+		} else {
+			opt.Payload += "#" + tmp[2]
+		}
+	}
+	return opt, nil
+}
+
+//isHTTPURL tells whether value is an absolute http or https URL
+func isHTTPURL(value string) bool {
+	return strings.HasPrefix(value, "http://") || strings.HasPrefix(value, "https://")
+}
+
+/*
+splitOption splits option on unescaped # into up to n fields, or as many as there are when n is 0, unescaping \# and \\
+*/
+func splitOption(option string, n int) []string {
+	var fields []string
+	var field strings.Builder
+	escaped := false
+	for _, r := range option {
+		switch {
+		case escaped:
+			if r != '#' && r != '\\' {
+				field.WriteRune('\\')
+			}
+			field.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '#' && (n <= 0 || len(fields) < n-1):
+			fields = append(fields, field.String())
+			field.Reset()
+		default:
+			field.WriteRune(r)
+		}
+	}
+	if escaped {
+		field.WriteRune('\\')
+	}
+	return append(fields, field.String())
+}
+
+/*
+GenerateButtonElements generates Messenger buttons.
+Format = buttonType#payload#url#buttontext
+
+When an option can't be parsed the error is logged and no element is returned.
+
+Deprecated: use GenerateButtonTemplateElements, which returns the parsing errors
 */
 func GenerateButtonElements(title string, subtitle string, imgURL string, options []string) (elements []*fbmodelsend.TemplateElement) {
-	tmplElem, _ := setTemplateElementForButtonMessage(title, subtitle, imgURL, options)
+	tmplElem, err := setTemplateElementForButtonMessage(title, subtitle, imgURL, options)
+	if err != nil {
+		log.Println("Error: ", err)
+		return []*fbmodelsend.TemplateElement{}
+	}
 	elements = []*fbmodelsend.TemplateElement{tmplElem}
 	return
 }
@@ -25,29 +174,34 @@ func setTemplateElementForButtonMessage(titulo string, subTitulo string, imgURL
 	err = nil
 	var btn []*fbmodelsend.Button
 	for _, bt := range opcoes {
-		//log.Printf("Bt: [%#v]\n", bt)
-		tmp := strings.Split(bt, "#")
-		if len(tmp) < 4 {
-			err = errors.New("[SetTemplateElementForButtonMessage] Button with invalid item number")
-			log.Println("Error: ", err)
+		opt, parseErr := ParseButtonOption(bt)
+		if parseErr != nil {
+			err = parseErr
 			return
 		}
-		buttontype := tmp[0]
-		payload := tmp[1]
-		url := tmp[2]
-		buttontext := tmp[3]
-		elem := new(fbmodelsend.Button)
-		elem.ButtonType = buttontype
-		if len(strings.TrimSpace(payload)) > 0 {
-			elem.Payload = payload
-		}
-		if len(url) > 0 {
-			elem.URL = url
-		}
-		elem.Title = buttontext
-		btn = append(btn, elem)
+		btn = append(btn, buttonFromOption(opt))
 	}
-	template = new(fbmodelsend.TemplateElement)
+	template = newButtonTemplateElement(titulo, subTitulo, imgURL, btn)
+	return
+}
+
+//buttonFromOption creates the button defined by opt
+func buttonFromOption(opt ButtonOption) *fbmodelsend.Button {
+	elem := new(fbmodelsend.Button)
+	elem.ButtonType = opt.ButtonType
+	if len(strings.TrimSpace(opt.Payload)) > 0 {
+		elem.Payload = opt.Payload
+	}
+	if len(opt.URL) > 0 {
+		elem.URL = opt.URL
+	}
+	elem.Title = opt.Title
+	return elem
+}
+
+//newButtonTemplateElement creates a template element with buttons
+func newButtonTemplateElement(titulo string, subTitulo string, imgURL string, btn []*fbmodelsend.Button) *fbmodelsend.TemplateElement {
+	template := new(fbmodelsend.TemplateElement)
 	template.Buttons = btn
 	template.Title = titulo
 	if len(subTitulo) > 1 {
@@ -56,7 +210,7 @@ func setTemplateElementForButtonMessage(titulo string, subTitulo string, imgURL
 	if len(imgURL) > 1 {
 		template.ImageURL = imgURL
 	}
-	return
+	return template
 }
 
 /*
@@ -70,12 +224,11 @@ func GenerateQuickReplyOptions(opcoes []string) (qrf []*fbmodelsend.QuickReply,
 		return
 	}
 	for _, opcao := range opcoes {
-		tmp := strings.Split(opcao, "#")
-		elem := new(fbmodelsend.QuickReply)
-		elem.ContentType = "text"
-		elem.Title = tmp[0]
-		elem.Payload = tmp[1]
-		qrf = append(qrf, elem)
+		opt, parseErr := ParseQuickReplyOption(opcao)
+		if parseErr != nil {
+			return nil, parseErr
+		}
+		qrf = append(qrf, fbmodelsend.NewImageQuickReply(opt.Title, opt.Payload, opt.ImageURL))
 	}
 	return
 }
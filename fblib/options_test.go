@@ -0,0 +1,116 @@
+package fblib
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/novatrixtech/go-fbmessenger/fbmodelsend"
+)
+
+func TestSplitOption(t *testing.T) {
+	tests := []struct {
+		option string
+		n      int
+		want   []string
+	}{
+		{"a#b#c#d", 4, []string{"a", "b", "c", "d"}},
+		{"a#b", 4, []string{"a", "b"}},
+		{"", 4, []string{""}},
+		{"a#b#c#d#e", 4, []string{"a", "b", "c", "d#e"}},
+		{`a\#b#c`, 4, []string{"a#b", "c"}},
+		{`a\\#b`, 4, []string{`a\`, "b"}},
+		{`a\\\#b`, 4, []string{`a\#b`}},
+		{`a\nb#c`, 4, []string{`a\nb`, "c"}},
+		{`a#b\`, 4, []string{"a", `b\`}},
+		{"#", 2, []string{"", ""}},
+		{`a#b#c\#d#e`, 0, []string{"a", "b", "c#d", "e"}},
+	}
+	for _, tt := range tests {
+		if got := splitOption(tt.option, tt.n); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("splitOption(%q, %d) = %q, want %q", tt.option, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestParseButtonOption(t *testing.T) {
+	tests := []struct {
+		option string
+		want   ButtonOption
+	}{
+		//URL fragments are kept in the URL of URL buttons
+		{`web_url##https://example.com/#top#Read \#1`, ButtonOption{ButtonType: "web_url", URL: "https://example.com/#top", Title: "Read #1"}},
+		{`web_url##https://example.com/\#top#Read`, ButtonOption{ButtonType: "web_url", URL: "https://example.com/#top", Title: "Read"}},
+		{`account_link##https://example.com/login#step=1#Log in`, ButtonOption{ButtonType: "account_link", URL: "https://example.com/login#step=1", Title: "Log in"}},
+		{`web_url##https://example.com/#Read`, ButtonOption{ButtonType: "web_url", URL: "https://example.com/", Title: "Read"}},
+		//Other buttons keep them in the button text
+		{`postback#ORDER\#42##Order \#42`, ButtonOption{ButtonType: "postback", Payload: "ORDER#42", Title: "Order #42"}},
+		{`postback#ORDER##Order #42`, ButtonOption{ButtonType: "postback", Payload: "ORDER", Title: "Order #42"}},
+	}
+	for _, tt := range tests {
+		got, err := ParseButtonOption(tt.option)
+		if err != nil {
+			t.Errorf("ParseButtonOption(%q) error = %v", tt.option, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseButtonOption(%q) = %+v, want %+v", tt.option, got, tt.want)
+		}
+	}
+
+	if _, err := ParseButtonOption("postback#PAYLOAD#Title"); err == nil {
+		t.Error("ParseButtonOption() with 3 fields error = nil, want error")
+	}
+}
+
+func TestParseQuickReplyOption(t *testing.T) {
+	tests := []struct {
+		option string
+		want   QuickReplyOption
+	}{
+		{"Yes#YES", QuickReplyOption{Title: "Yes", Payload: "YES"}},
+		{"Red#COLOR_RED#https://example.com/red.png", QuickReplyOption{Title: "Red", Payload: "COLOR_RED", ImageURL: "https://example.com/red.png"}},
+		{"Size#SIZE#M", QuickReplyOption{Title: "Size", Payload: "SIZE#M"}},
+		{`Item \#1#ITEM_1`, QuickReplyOption{Title: "Item #1", Payload: "ITEM_1"}},
+	}
+	for _, tt := range tests {
+		got, err := ParseQuickReplyOption(tt.option)
+		if err != nil {
+			t.Errorf("ParseQuickReplyOption(%q) error = %v", tt.option, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseQuickReplyOption(%q) = %+v, want %+v", tt.option, got, tt.want)
+		}
+	}
+	if _, err := ParseQuickReplyOption("Yes"); err == nil {
+		t.Error("ParseQuickReplyOption() without payload error = nil, want error")
+	}
+}
+
+func TestGenerateButtonTemplateElements(t *testing.T) {
+	elements, err := GenerateButtonTemplateElements("Title", "", "", []ButtonOption{
+		{ButtonType: fbmodelsend.ButtonTypePostback, Payload: "BUY", Title: "Buy"},
+	})
+	if err != nil {
+		t.Fatalf("GenerateButtonTemplateElements() error = %v", err)
+	}
+	if len(elements) != 1 || len(elements[0].Buttons) != 1 {
+		t.Fatalf("GenerateButtonTemplateElements() = %v, want one element with one button", elements)
+	}
+
+	if _, err := GenerateButtonTemplateElements("Title", "", "", []ButtonOption{{ButtonType: "bad", Title: "Buy"}}); err == nil {
+		t.Error("GenerateButtonTemplateElements() with invalid button error = nil, want error")
+	}
+}
+
+func TestGenerateButtonElementsInvalidOption(t *testing.T) {
+	elements := GenerateButtonElements("Title", "", "", []string{"bad"})
+	if elements == nil || len(elements) != 0 {
+		t.Fatalf("GenerateButtonElements() with invalid option = %v, want an empty slice", elements)
+	}
+
+	elements = GenerateButtonElements("Title", "", "", []string{"postback#BUY##Buy"})
+	if len(elements) != 1 || elements[0] == nil || elements[0].Buttons[0].Payload != "BUY" {
+		t.Errorf("GenerateButtonElements() = %v, want one element with the BUY button", elements)
+	}
+}
@@ -0,0 +1,98 @@
+package fblib
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/novatrixtech/go-fbmessenger/fbmodelsend"
+)
+
+//personasPath is the Personas API endpoint relative to the Graph API version
+const personasPath = "me/personas"
+
+/*
+CreatePersona - Creates a Persona of the Page and returns its ID, to be used with WithPersona
+*/
+func (c *Client) CreatePersona(ctx context.Context, name string, profilePictureURL string) (personaID string, err error) {
+	if name == "" || profilePictureURL == "" {
+		return "", errors.New("[CreatePersona] Name and profile picture URL are required")
+	}
+	persona := &fbmodelsend.Persona{
+		Name:              name,
+		ProfilePictureURL: profilePictureURL,
+	}
+
+	var resp struct {
+		ID string `json:"id"`
+	}
+	if err = c.doGraphRequest(ctx, http.MethodPost, personasPath, nil, persona, &resp); err != nil {
+		return "", err
+	}
+	if resp.ID == "" {
+		return "", errors.New("[CreatePersona] Facebook didn't return the persona ID")
+	}
+	return resp.ID, nil
+}
+
+/*
+GetPersona - Gets the Persona personaID
+*/
+func (c *Client) GetPersona(ctx context.Context, personaID string) (*fbmodelsend.Persona, error) {
+	if personaID == "" {
+		return nil, errors.New("[GetPersona] Persona ID is required")
+	}
+	persona := new(fbmodelsend.Persona)
+	if err := c.doGraphRequest(ctx, http.MethodGet, personaID, nil, nil, persona); err != nil {
+		return nil, err
+	}
+	return persona, nil
+}
+
+/*
+ListPersonas - Lists every Persona of the Page, following the pages of the Graph API response
+*/
+func (c *Client) ListPersonas(ctx context.Context) ([]*fbmodelsend.Persona, error) {
+	var personas []*fbmodelsend.Persona
+	query := url.Values{}
+	for {
+		var resp struct {
+			Data   []*fbmodelsend.Persona `json:"data"`
+			Paging struct {
+				Cursors struct {
+					After string `json:"after"`
+				} `json:"cursors"`
+				Next string `json:"next"`
+			} `json:"paging"`
+		}
+		if err := c.doGraphRequest(ctx, http.MethodGet, personasPath, query, nil, &resp); err != nil {
+			return nil, err
+		}
+		personas = append(personas, resp.Data...)
+		if resp.Paging.Next == "" || resp.Paging.Cursors.After == "" {
+			return personas, nil
+		}
+		query = url.Values{}
+		query.Set("after", resp.Paging.Cursors.After)
+	}
+}
+
+/*
+DeletePersona - Deletes the Persona personaID. Messages already sent as the Persona are kept.
+*/
+func (c *Client) DeletePersona(ctx context.Context, personaID string) error {
+	if personaID == "" {
+		return errors.New("[DeletePersona] Persona ID is required")
+	}
+	var resp struct {
+		Success bool `json:"success"`
+	}
+	if err := c.doGraphRequest(ctx, http.MethodDelete, personaID, nil, nil, &resp); err != nil {
+		return err
+	}
+	if !resp.Success {
+		return errors.New("[DeletePersona] Facebook didn't confirm the deletion of the persona " + personaID)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package fblib
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListPersonasFollowsCursors(t *testing.T) {
+	//The last page still has cursors, but no next link
+	pages := map[string]string{
+		"": `{"data":[{"id":"1","name":"Jane"},{"id":"2","name":"John"}],
+			"paging":{"cursors":{"before":"b1","after":"a1"},"next":"https://graph.facebook.com/v6.0/me/personas?after=a1"}}`,
+		"a1": `{"data":[{"id":"3","name":"Ann"}],
+			"paging":{"cursors":{"before":"b2","after":"a2"}}}`,
+	}
+	var afters []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		after := r.URL.Query().Get("after")
+		afters = append(afters, after)
+		body, ok := pages[after]
+		if !ok {
+			t.Errorf("unexpected cursor %q", after)
+			body = `{"data":[]}`
+		}
+		w.Write([]byte(body))
+	}))
+	defer srv.Close()
+	c := NewClient("token", WithBaseURL(srv.URL))
+
+	personas, err := c.ListPersonas(context.Background())
+	if err != nil {
+		t.Fatalf("ListPersonas() error = %v", err)
+	}
+	if len(afters) != 2 || afters[1] != "a1" {
+		t.Errorf("cursors requested = %q, want the first page and a1", afters)
+	}
+	want := []string{"1", "2", "3"}
+	if len(personas) != len(want) {
+		t.Fatalf("personas = %d, want %d", len(personas), len(want))
+	}
+	for i, id := range want {
+		if personas[i].ID != id {
+			t.Errorf("personas[%d].ID = %q, want %q", i, personas[i].ID, id)
+		}
+	}
+}
+
+func TestDeletePersona(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr bool
+	}{
+		{"confirmed", `{"success":true}`, false},
+		{"not confirmed", `{"success":false}`, true},
+	}
+	for _, tt := range tests {
+		var method, path string
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			method, path = r.Method, r.URL.Path
+			w.Write([]byte(tt.body))
+		}))
+		c := NewClient("token", WithBaseURL(srv.URL))
+
+		err := c.DeletePersona(context.Background(), "persona.1")
+		srv.Close()
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: DeletePersona() error = %v, want error %v", tt.name, err, tt.wantErr)
+		}
+		if method != http.MethodDelete || path != "/"+DefaultAPIVersion+"/persona.1" {
+			t.Errorf("%s: request = %s %s, want DELETE of the persona", tt.name, method, path)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package fblib
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/novatrixtech/go-fbmessenger/fbmodelsend"
+)
+
+//profilePath is the Messenger Profile API endpoint relative to the Graph API version
+const profilePath = "me/messenger_profile"
+
+/*
+GetMessengerProfile - Gets the Messenger Profile of the Page. Without fields every field modeled by
+fbmodelsend.MessengerProfile is returned.
+*/
+func (c *Client) GetMessengerProfile(ctx context.Context, fields ...string) (*fbmodelsend.MessengerProfile, error) {
+	if len(fields) == 0 {
+		fields = fbmodelsend.ProfileFields
+	}
+	query := url.Values{}
+	query.Set("fields", strings.Join(fields, ","))
+
+	var resp struct {
+		Data []*fbmodelsend.MessengerProfile `json:"data"`
+	}
+	if err := c.doGraphRequest(ctx, http.MethodGet, profilePath, query, nil, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Data) == 0 || resp.Data[0] == nil {
+		return new(fbmodelsend.MessengerProfile), nil
+	}
+	return resp.Data[0], nil
+}
+
+/*
+SetMessengerProfile - Sets the fields of the Messenger Profile present in profile, after checking their limits.
+Fields not set in profile are kept as they are. Since Facebook requires the Get Started button whenever there is
+a persistent menu, a profile setting persistent_menu must also carry get_started.
+*/
+func (c *Client) SetMessengerProfile(ctx context.Context, profile *fbmodelsend.MessengerProfile) error {
+	if profile == nil {
+		return errors.New("[SetMessengerProfile] Profile is required")
+	}
+	if err := profile.Validate(); err != nil {
+		return err
+	}
+	return c.doGraphRequest(ctx, http.MethodPost, profilePath, nil, profile, nil)
+}
+
+/*
+DeleteMessengerProfileFields - Deletes fields (get_started, greeting, persistent_menu...) from the Messenger Profile
+*/
+func (c *Client) DeleteMessengerProfileFields(ctx context.Context, fields ...string) error {
+	if len(fields) == 0 {
+		return errors.New("[DeleteMessengerProfileFields] It's necessary send at least one field")
+	}
+	body := struct {
+		Fields []string `json:"fields"`
+	}{fields}
+	return c.doGraphRequest(ctx, http.MethodDelete, profilePath, nil, body, nil)
+}
@@ -0,0 +1,272 @@
+package fblib
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/novatrixtech/go-fbmessenger/fbmodelsend"
+)
+
+//ProfileChangeSet is a field that is added or modified by SyncMessengerProfile
+const ProfileChangeSet = "set"
+
+//ProfileChangeDelete is a field that is removed by SyncMessengerProfile
+const ProfileChangeDelete = "delete"
+
+/*
+ProfileChange - Change of one Messenger Profile field. Current and Desired are the JSON values, empty when absent.
+*/
+type ProfileChange struct {
+	Field   string
+	Action  string
+	Current string
+	Desired string
+}
+
+/*
+ProfileDiff - Changes needed to take the Messenger Profile from what Facebook has to what is desired
+*/
+type ProfileDiff struct {
+	Changes []ProfileChange
+}
+
+/*
+Empty tells whether the Messenger Profile is already as desired
+*/
+func (d *ProfileDiff) Empty() bool {
+	return len(d.Changes) == 0
+}
+
+//String prints the diff, one field per block, with - for the current value and + for the desired one
+func (d *ProfileDiff) String() string {
+	if d.Empty() {
+		return "Messenger Profile is up to date\n"
+	}
+	var out strings.Builder
+	for _, change := range d.Changes {
+		fmt.Fprintf(&out, "%s %s\n", change.Action, change.Field)
+		if change.Current != "" {
+			fmt.Fprintf(&out, "  - %s\n", change.Current)
+		}
+		if change.Desired != "" {
+			fmt.Fprintf(&out, "  + %s\n", change.Desired)
+		}
+	}
+	return out.String()
+}
+
+/*
+LoadMessengerProfile reads the desired Messenger Profile from a JSON file with the same structure
+sent to the Messenger Profile API
+*/
+func LoadMessengerProfile(path string) (*fbmodelsend.MessengerProfile, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	profile := new(fbmodelsend.MessengerProfile)
+	if err := decoder.Decode(profile); err != nil {
+		return nil, fmt.Errorf("[LoadMessengerProfile] Invalid profile file [%s]: %s", path, err.Error())
+	}
+	return profile, nil
+}
+
+/*
+DiffMessengerProfile compares the fields of fbmodelsend.ProfileFields set in desired. Fields absent from desired
+are left as they are, unless prune is true: then they are deleted. Differences Facebook doesn't keep, such as
+the order of the locales or a trailing / in whitelisted domains, are ignored.
+*/
+func DiffMessengerProfile(current *fbmodelsend.MessengerProfile, desired *fbmodelsend.MessengerProfile, prune bool) (*ProfileDiff, error) {
+	if desired == nil {
+		return nil, errors.New("[DiffMessengerProfile] Desired profile is required")
+	}
+	if current == nil {
+		current = new(fbmodelsend.MessengerProfile)
+	}
+	diff := new(ProfileDiff)
+	for _, field := range fbmodelsend.ProfileFields {
+		currentValue, err := profileFieldJSON(current, field)
+		if err != nil {
+			return nil, err
+		}
+		desiredValue, err := profileFieldJSON(desired, field)
+		if err != nil {
+			return nil, err
+		}
+		if currentValue == desiredValue || (desiredValue == "" && !prune) {
+			continue
+		}
+		action := ProfileChangeSet
+		if desiredValue == "" {
+			action = ProfileChangeDelete
+		}
+		diff.Changes = append(diff.Changes, ProfileChange{
+			Field:   field,
+			Action:  action,
+			Current: currentValue,
+			Desired: desiredValue,
+		})
+	}
+	return diff, nil
+}
+
+/*
+SyncMessengerProfile takes the Messenger Profile of the Page to desired, changing only the fields that differ.
+Fields absent from desired are kept, unless prune is true: then they are deleted. The resulting profile is
+checked before anything is changed. With dryRun nothing is changed and the returned diff tells what would be.
+*/
+func (c *Client) SyncMessengerProfile(ctx context.Context, desired *fbmodelsend.MessengerProfile, dryRun bool, prune bool) (*ProfileDiff, error) {
+	if desired == nil {
+		return nil, errors.New("[SyncMessengerProfile] Desired profile is required")
+	}
+	current, err := c.GetMessengerProfile(ctx)
+	if err != nil {
+		return nil, err
+	}
+	diff, err := DiffMessengerProfile(current, desired, prune)
+	if err != nil {
+		return nil, err
+	}
+
+	//merged is the profile Facebook will have after the sync. It's checked before the first write,
+	//so a sync that would leave, for example, a persistent menu without get started changes nothing.
+	merged := *current
+	changes := new(fbmodelsend.MessengerProfile)
+	var deletes []string
+	for _, change := range diff.Changes {
+		if change.Action == ProfileChangeDelete {
+			copyProfileField(&merged, new(fbmodelsend.MessengerProfile), change.Field)
+			deletes = append(deletes, change.Field)
+			continue
+		}
+		copyProfileField(&merged, desired, change.Field)
+		copyProfileField(changes, desired, change.Field)
+	}
+	if err := merged.Validate(); err != nil {
+		return nil, err
+	}
+	if dryRun || diff.Empty() {
+		return diff, nil
+	}
+	//Facebook requires get started in the same call that sets the persistent menu
+	if changes.PersistentMenu != nil && changes.GetStarted == nil {
+		changes.GetStarted = merged.GetStarted
+	}
+
+	if len(deletes) < len(diff.Changes) {
+		if err := c.SetMessengerProfile(ctx, changes); err != nil {
+			return nil, err
+		}
+	}
+	if len(deletes) > 0 {
+		if err := c.DeleteMessengerProfileFields(ctx, deletes...); err != nil {
+			return nil, err
+		}
+	}
+	return diff, nil
+}
+
+//profileFieldJSON returns the normalized JSON of field in profile, or an empty string when it's not set
+func profileFieldJSON(profile *fbmodelsend.MessengerProfile, field string) (string, error) {
+	var value interface{}
+	switch field {
+	case fbmodelsend.ProfileFieldGetStarted:
+		if profile.GetStarted == nil {
+			return "", nil
+		}
+		value = profile.GetStarted
+	case fbmodelsend.ProfileFieldGreeting:
+		if len(profile.Greeting) == 0 {
+			return "", nil
+		}
+		value = sortedGreetings(profile.Greeting)
+	case fbmodelsend.ProfileFieldPersistentMenu:
+		if len(profile.PersistentMenu) == 0 {
+			return "", nil
+		}
+		value = sortedMenus(profile.PersistentMenu)
+	case fbmodelsend.ProfileFieldIceBreakers:
+		if len(profile.IceBreakers) == 0 {
+			return "", nil
+		}
+		value = profile.IceBreakers
+	case fbmodelsend.ProfileFieldWhitelistedDomains:
+		if len(profile.WhitelistedDomains) == 0 {
+			return "", nil
+		}
+		value = normalizedDomains(profile.WhitelistedDomains)
+	default:
+		return "", fmt.Errorf("[profileFieldJSON] Unknown Messenger Profile field [%s]", field)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+//copyProfileField copies field from src to dst
+func copyProfileField(dst *fbmodelsend.MessengerProfile, src *fbmodelsend.MessengerProfile, field string) {
+	switch field {
+	case fbmodelsend.ProfileFieldGetStarted:
+		dst.GetStarted = src.GetStarted
+	case fbmodelsend.ProfileFieldGreeting:
+		dst.Greeting = src.Greeting
+	case fbmodelsend.ProfileFieldPersistentMenu:
+		dst.PersistentMenu = src.PersistentMenu
+	case fbmodelsend.ProfileFieldIceBreakers:
+		dst.IceBreakers = src.IceBreakers
+	case fbmodelsend.ProfileFieldWhitelistedDomains:
+		dst.WhitelistedDomains = src.WhitelistedDomains
+	}
+}
+
+//sortedGreetings returns a copy of greetings sorted by locale
+func sortedGreetings(greetings []*fbmodelsend.Greeting) []*fbmodelsend.Greeting {
+	sorted := append([]*fbmodelsend.Greeting(nil), greetings...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return greetingLocale(sorted[i]) < greetingLocale(sorted[j])
+	})
+	return sorted
+}
+
+func greetingLocale(greeting *fbmodelsend.Greeting) string {
+	if greeting == nil {
+		return ""
+	}
+	return greeting.Locale
+}
+
+//sortedMenus returns a copy of menus sorted by locale. The order of the items of each menu is kept.
+func sortedMenus(menus []*fbmodelsend.PersistentMenu) []*fbmodelsend.PersistentMenu {
+	sorted := append([]*fbmodelsend.PersistentMenu(nil), menus...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return menuLocale(sorted[i]) < menuLocale(sorted[j])
+	})
+	return sorted
+}
+
+func menuLocale(menu *fbmodelsend.PersistentMenu) string {
+	if menu == nil {
+		return ""
+	}
+	return menu.Locale
+}
+
+//normalizedDomains returns a sorted copy of domains without the trailing / Facebook adds to them
+func normalizedDomains(domains []string) []string {
+	normalized := make([]string, len(domains))
+	for i, domain := range domains {
+		normalized[i] = strings.TrimRight(domain, "/")
+	}
+	sort.Strings(normalized)
+	return normalized
+}
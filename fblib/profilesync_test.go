@@ -0,0 +1,207 @@
+package fblib
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/novatrixtech/go-fbmessenger/fbmodelsend"
+)
+
+func testDesiredProfile() *fbmodelsend.MessengerProfile {
+	return &fbmodelsend.MessengerProfile{
+		GetStarted: &fbmodelsend.GetStarted{Payload: "GET_STARTED"},
+		Greeting: []*fbmodelsend.Greeting{
+			{Locale: fbmodelsend.DefaultLocale, Text: "Hello!"},
+			{Locale: "pt_BR", Text: "Olá!"},
+		},
+		PersistentMenu: []*fbmodelsend.PersistentMenu{
+			{Locale: fbmodelsend.DefaultLocale, CallToActions: []*fbmodelsend.MenuItem{{Type: "postback", Title: "Help", Payload: "HELP"}}},
+			{Locale: "pt_BR", CallToActions: []*fbmodelsend.MenuItem{{Type: "postback", Title: "Ajuda", Payload: "HELP"}}},
+		},
+		WhitelistedDomains: []string{"https://shop.example.com", "https://example.com"},
+	}
+}
+
+func TestDiffMessengerProfileIgnoresOrderAndTrailingSlash(t *testing.T) {
+	desired := testDesiredProfile()
+	current := testDesiredProfile()
+	current.Greeting[0], current.Greeting[1] = current.Greeting[1], current.Greeting[0]
+	current.PersistentMenu[0], current.PersistentMenu[1] = current.PersistentMenu[1], current.PersistentMenu[0]
+	current.WhitelistedDomains = []string{"https://example.com/", "https://shop.example.com/"}
+
+	diff, err := DiffMessengerProfile(current, desired, true)
+	if err != nil {
+		t.Fatalf("DiffMessengerProfile() error = %v", err)
+	}
+	if !diff.Empty() {
+		t.Errorf("DiffMessengerProfile() = %s, want empty", diff)
+	}
+	if desired.Greeting[0].Locale != fbmodelsend.DefaultLocale || desired.WhitelistedDomains[0] != "https://shop.example.com" {
+		t.Error("DiffMessengerProfile() changed desired")
+	}
+}
+
+func TestDiffMessengerProfileChanges(t *testing.T) {
+	desired := testDesiredProfile()
+	desired.GetStarted = nil
+	current := testDesiredProfile()
+	current.Greeting[1].Text = "Oi!"
+
+	tests := []struct {
+		prune bool
+		want  map[string]string
+	}{
+		{false, map[string]string{
+			fbmodelsend.ProfileFieldGreeting: ProfileChangeSet,
+		}},
+		{true, map[string]string{
+			fbmodelsend.ProfileFieldGetStarted: ProfileChangeDelete,
+			fbmodelsend.ProfileFieldGreeting:   ProfileChangeSet,
+		}},
+	}
+	for _, tt := range tests {
+		diff, err := DiffMessengerProfile(current, desired, tt.prune)
+		if err != nil {
+			t.Fatalf("DiffMessengerProfile() error = %v", err)
+		}
+		actions := make(map[string]string)
+		for _, change := range diff.Changes {
+			actions[change.Field] = change.Action
+		}
+		if len(actions) != len(tt.want) {
+			t.Errorf("DiffMessengerProfile(prune %v) = %s, want %v", tt.prune, diff, tt.want)
+			continue
+		}
+		for field, action := range tt.want {
+			if actions[field] != action {
+				t.Errorf("prune %v: change of %s = %q, want %q", tt.prune, field, actions[field], action)
+			}
+		}
+	}
+
+	if _, err := DiffMessengerProfile(current, nil, false); err == nil {
+		t.Error("DiffMessengerProfile() without desired error = nil, want error")
+	}
+	if diff, err := DiffMessengerProfile(nil, desired, false); err != nil || diff.Empty() {
+		t.Errorf("DiffMessengerProfile() without current = %v, %v, want every desired field set", diff, err)
+	}
+}
+
+func TestSyncMessengerProfileDryRun(t *testing.T) {
+	var posts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			atomic.AddInt32(&posts, 1)
+		}
+		w.Write([]byte(`{"data":[{
+			"get_started":{"payload":"GET_STARTED"},
+			"greeting":[{"locale":"pt_BR","text":"Olá!"},{"locale":"default","text":"Hello!"}],
+			"persistent_menu":[
+				{"locale":"pt_BR","composer_input_disabled":false,"call_to_actions":[{"type":"postback","title":"Ajuda","payload":"HELP"}]},
+				{"locale":"default","composer_input_disabled":false,"call_to_actions":[{"type":"postback","title":"Help","payload":"HELP"}]}
+			],
+			"whitelisted_domains":["https://example.com/","https://shop.example.com/"]
+		}]}`))
+	}))
+	defer srv.Close()
+	c := NewClient("token", WithBaseURL(srv.URL))
+
+	diff, err := c.SyncMessengerProfile(context.Background(), testDesiredProfile(), true, true)
+	if err != nil {
+		t.Fatalf("SyncMessengerProfile() error = %v", err)
+	}
+	if !diff.Empty() {
+		t.Errorf("SyncMessengerProfile() = %s, want empty", diff)
+	}
+	if n := atomic.LoadInt32(&posts); n != 0 {
+		t.Errorf("dry run changed the profile %d times", n)
+	}
+
+	if _, err := c.SyncMessengerProfile(context.Background(), nil, true, false); err == nil {
+		t.Error("SyncMessengerProfile() without desired error = nil, want error")
+	}
+}
+
+//newProfileSyncTestServer serves current on GET and records the body of the other calls by method
+func newProfileSyncTestServer(t *testing.T, current *fbmodelsend.MessengerProfile) (*httptest.Server, map[string][]byte) {
+	writes := make(map[string][]byte)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": []*fbmodelsend.MessengerProfile{current}})
+			return
+		}
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("reading %s body: %v", r.Method, err)
+		}
+		writes[r.Method] = body
+		w.Write([]byte(`{"result":"success"}`))
+	}))
+	return srv, writes
+}
+
+func TestSyncMessengerProfileKeepsAbsentFields(t *testing.T) {
+	desired := &fbmodelsend.MessengerProfile{Greeting: []*fbmodelsend.Greeting{{Locale: fbmodelsend.DefaultLocale, Text: "Hi!"}}}
+	srv, writes := newProfileSyncTestServer(t, testDesiredProfile())
+	defer srv.Close()
+	c := NewClient("token", WithBaseURL(srv.URL))
+
+	diff, err := c.SyncMessengerProfile(context.Background(), desired, false, false)
+	if err != nil {
+		t.Fatalf("SyncMessengerProfile() error = %v", err)
+	}
+	if len(diff.Changes) != 1 || diff.Changes[0].Field != fbmodelsend.ProfileFieldGreeting {
+		t.Errorf("SyncMessengerProfile() = %s, want only greeting set", diff)
+	}
+	if _, ok := writes[http.MethodDelete]; ok {
+		t.Errorf("SyncMessengerProfile() deleted %s without prune", writes[http.MethodDelete])
+	}
+	var set map[string]json.RawMessage
+	if err := json.Unmarshal(writes[http.MethodPost], &set); err != nil || len(set) != 1 || set["greeting"] == nil {
+		t.Errorf("SyncMessengerProfile() set %s, want only greeting", writes[http.MethodPost])
+	}
+}
+
+func TestSyncMessengerProfileValidatesBeforeWriting(t *testing.T) {
+	//With prune get_started would be deleted while the persistent menu stays
+	desired := testDesiredProfile()
+	desired.GetStarted = nil
+	desired.Greeting[0].Text = "Hi!"
+	srv, writes := newProfileSyncTestServer(t, testDesiredProfile())
+	defer srv.Close()
+	c := NewClient("token", WithBaseURL(srv.URL))
+
+	_, err := c.SyncMessengerProfile(context.Background(), desired, false, true)
+	var validationErr *fbmodelsend.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("SyncMessengerProfile() error = %v, want *fbmodelsend.ValidationError", err)
+	}
+	if len(writes) != 0 {
+		t.Errorf("SyncMessengerProfile() wrote %d times before failing", len(writes))
+	}
+}
+
+func TestSyncMessengerProfileSetsMenuWithGetStarted(t *testing.T) {
+	desired := &fbmodelsend.MessengerProfile{PersistentMenu: testDesiredProfile().PersistentMenu}
+	desired.PersistentMenu[0].CallToActions[0].Title = "Support"
+	srv, writes := newProfileSyncTestServer(t, testDesiredProfile())
+	defer srv.Close()
+	c := NewClient("token", WithBaseURL(srv.URL))
+
+	if _, err := c.SyncMessengerProfile(context.Background(), desired, false, false); err != nil {
+		t.Fatalf("SyncMessengerProfile() error = %v", err)
+	}
+	var set fbmodelsend.MessengerProfile
+	if err := json.Unmarshal(writes[http.MethodPost], &set); err != nil {
+		t.Fatalf("decoding the set body %s: %v", writes[http.MethodPost], err)
+	}
+	if set.PersistentMenu == nil || set.GetStarted == nil || set.GetStarted.Payload != "GET_STARTED" {
+		t.Errorf("SyncMessengerProfile() set %s, want persistent_menu with the current get_started", writes[http.MethodPost])
+	}
+}
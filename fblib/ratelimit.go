@@ -0,0 +1,186 @@
+package fblib
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+//ErrRateLimited is returned when a RateLimiter with RateLimitFailFast policy has no token available
+var ErrRateLimited = errors.New("fblib: client-side rate limit exceeded")
+
+//RateLimitPolicy defines what a RateLimiter does when there is no token available
+type RateLimitPolicy int
+
+//RateLimitWait blocks the call until a token is available or the context is done
+const RateLimitWait RateLimitPolicy = 0
+
+//RateLimitFailFast returns ErrRateLimited right away
+const RateLimitFailFast RateLimitPolicy = 1
+
+//maxIdleBuckets is the number of buckets kept before the idle ones are dropped
+const maxIdleBuckets = 10000
+
+/*
+RateLimiter is a token bucket limiter with one bucket per key.
+Used as page limiter the key is the Page Access Token, so a RateLimiter shared by
+many Clients of the same Page paces all of them together.
+Used as recipient limiter the key is the recipient PSID, and messages to the same
+recipient are also sent one at a time, in the order they were requested.
+*/
+type RateLimiter struct {
+	perSecond float64
+	burst     int
+	policy    RateLimitPolicy
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+	//turn serializes the calls to the same key
+	turn chan struct{}
+	//users counts the acquire calls waiting for or holding turn. l.mu must be held to use it.
+	users int
+}
+
+/*
+NewRateLimiter creates a RateLimiter allowing perSecond calls per key with bursts of up to burst calls
+*/
+func NewRateLimiter(perSecond float64, burst int, policy RateLimitPolicy) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		perSecond: perSecond,
+		burst:     burst,
+		policy:    policy,
+		buckets:   make(map[string]*bucket),
+	}
+}
+
+/*
+WithPageRateLimiter paces every Graph API call made with the Page Access Token of the Client
+*/
+func WithPageRateLimiter(limiter *RateLimiter) ClientOption {
+	return func(c *Client) {
+		c.pageLimiter = limiter
+	}
+}
+
+/*
+WithRecipientRateLimiter paces and orders the messages sent to each recipient.
+The typing indicators of WhileTyping and TypingIndicator are not paced by it.
+*/
+func WithRecipientRateLimiter(limiter *RateLimiter) ClientOption {
+	return func(c *Client) {
+		c.recipientLimiter = limiter
+	}
+}
+
+//bucket returns the bucket of key refilled up to now. l.mu must be held.
+func (l *RateLimiter) bucket(key string, now time.Time) *bucket {
+	b, ok := l.buckets[key]
+	if !ok {
+		if len(l.buckets) >= maxIdleBuckets {
+			l.dropIdleBuckets(now)
+		}
+		b = &bucket{tokens: float64(l.burst), last: now, turn: make(chan struct{}, 1)}
+		l.buckets[key] = b
+		return b
+	}
+	b.tokens += now.Sub(b.last).Seconds() * l.perSecond
+	if b.tokens > float64(l.burst) {
+		b.tokens = float64(l.burst)
+	}
+	b.last = now
+	return b
+}
+
+//dropIdleBuckets removes the buckets that are full and not in use. l.mu must be held.
+func (l *RateLimiter) dropIdleBuckets(now time.Time) {
+	for key, b := range l.buckets {
+		full := b.tokens+now.Sub(b.last).Seconds()*l.perSecond >= float64(l.burst)
+		if full && b.users == 0 {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+/*
+Wait takes a token from the bucket of key, waiting for it according to the policy
+*/
+func (l *RateLimiter) Wait(ctx context.Context, key string) error {
+	l.mu.Lock()
+	b := l.bucket(key, time.Now())
+	if b.tokens >= 1 {
+		b.tokens--
+		l.mu.Unlock()
+		return nil
+	}
+	if l.policy == RateLimitFailFast || l.perSecond <= 0 {
+		l.mu.Unlock()
+		return ErrRateLimited
+	}
+	//The token is reserved now so concurrent callers queue behind each other
+	wait := time.Duration((1 - b.tokens) / l.perSecond * float64(time.Second))
+	b.tokens--
+	l.mu.Unlock()
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		l.mu.Lock()
+		b.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+/*
+acquire waits for the turn of key and then for a token. release must be called once the call is done.
+*/
+func (l *RateLimiter) acquire(ctx context.Context, key string) (release func(), err error) {
+	//users is counted while l.mu is held so the bucket can't be dropped, and replaced by a new one, before its turn is taken
+	l.mu.Lock()
+	b := l.bucket(key, time.Now())
+	b.users++
+	l.mu.Unlock()
+	done := func() {
+		l.mu.Lock()
+		b.users--
+		l.mu.Unlock()
+	}
+
+	if l.policy == RateLimitFailFast {
+		select {
+		case b.turn <- struct{}{}:
+		default:
+			done()
+			return nil, ErrRateLimited
+		}
+	} else {
+		select {
+		case b.turn <- struct{}{}:
+		case <-ctx.Done():
+			done()
+			return nil, ctx.Err()
+		}
+	}
+	release = func() {
+		<-b.turn
+		done()
+	}
+
+	if err = l.Wait(ctx, key); err != nil {
+		release()
+		return nil, err
+	}
+	return release, nil
+}
@@ -0,0 +1,187 @@
+package fblib
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterFailFast(t *testing.T) {
+	l := NewRateLimiter(1, 2, RateLimitFailFast)
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if err := l.Wait(ctx, "page"); err != nil {
+			t.Fatalf("Wait() %d within burst error = %v", i, err)
+		}
+	}
+	if err := l.Wait(ctx, "page"); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("Wait() over burst error = %v, want ErrRateLimited", err)
+	}
+	if err := l.Wait(ctx, "other page"); err != nil {
+		t.Errorf("Wait() on another key error = %v, want nil", err)
+	}
+}
+
+func TestRateLimiterWaitPacesCallers(t *testing.T) {
+	l := NewRateLimiter(50, 1, RateLimitWait)
+	ctx := context.Background()
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := l.Wait(ctx, "page"); err != nil {
+			t.Fatalf("Wait() error = %v", err)
+		}
+	}
+	//The first token is in the bucket, the other 3 come every 20ms
+	if elapsed := time.Since(start); elapsed < 55*time.Millisecond {
+		t.Errorf("4 calls at 50/s with burst 1 took %v, want at least 60ms", elapsed)
+	}
+}
+
+func TestRateLimiterWaitGivesTokenBackOnCancel(t *testing.T) {
+	l := NewRateLimiter(1, 1, RateLimitWait)
+	if err := l.Wait(context.Background(), "page"); err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx, "page"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Wait() error = %v, want context.DeadlineExceeded", err)
+	}
+	l.mu.Lock()
+	tokens := l.buckets["page"].tokens
+	l.mu.Unlock()
+	if tokens < -0.5 {
+		t.Errorf("tokens after cancel = %v, the reserved token wasn't given back", tokens)
+	}
+}
+
+func TestRateLimiterAcquireKeepsOrderPerKey(t *testing.T) {
+	l := NewRateLimiter(1000, 1000, RateLimitWait)
+	ctx := context.Background()
+
+	release, err := l.acquire(ctx, "recipient")
+	if err != nil {
+		t.Fatalf("acquire() error = %v", err)
+	}
+
+	var mu sync.Mutex
+	var order []int
+	var wg sync.WaitGroup
+	for i := 1; i <= 3; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			r, err := l.acquire(ctx, "recipient")
+			if err != nil {
+				t.Errorf("acquire() error = %v", err)
+				return
+			}
+			mu.Lock()
+			order = append(order, i)
+			mu.Unlock()
+			r()
+		}(i)
+		//Gives the goroutine time to queue before the next one
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	mu.Lock()
+	if len(order) != 0 {
+		t.Errorf("calls ran while the turn was held: %v", order)
+	}
+	mu.Unlock()
+	release()
+	wg.Wait()
+	if len(order) != 3 {
+		t.Fatalf("calls = %v, want 3", order)
+	}
+}
+
+func TestRateLimiterAcquireFailFastWhileBusy(t *testing.T) {
+	l := NewRateLimiter(1000, 1000, RateLimitFailFast)
+	release, err := l.acquire(context.Background(), "recipient")
+	if err != nil {
+		t.Fatalf("acquire() error = %v", err)
+	}
+	if _, err := l.acquire(context.Background(), "recipient"); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("acquire() while busy error = %v, want ErrRateLimited", err)
+	}
+	release()
+	if r, err := l.acquire(context.Background(), "recipient"); err != nil {
+		t.Errorf("acquire() after release error = %v", err)
+	} else {
+		r()
+	}
+}
+
+func TestClientFailFastLimiterIsNotRetried(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Write([]byte(`{"recipient_id":"1"}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient("token",
+		WithBaseURL(srv.URL),
+		WithPageRateLimiter(NewRateLimiter(0.001, 1, RateLimitFailFast)),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 3, InitialBackoff: 100 * time.Millisecond, Multiplier: 2}),
+	)
+	ctx := context.Background()
+	if _, err := c.SendTextMessage(ctx, "hi", "1", MessageTypeResponse); err != nil {
+		t.Fatalf("first SendTextMessage() error = %v", err)
+	}
+
+	start := time.Now()
+	_, err := c.SendTextMessage(ctx, "hi", "1", MessageTypeResponse)
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("second SendTextMessage() error = %v, want ErrRateLimited", err)
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("fail fast took %v, it was retried", elapsed)
+	}
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Errorf("calls = %d, want 1", n)
+	}
+}
+
+func TestRateLimiterKeepsBucketsInUse(t *testing.T) {
+	l := NewRateLimiter(1000, 1000, RateLimitWait)
+
+	//An acquire call that counted itself but didn't take the turn yet
+	l.mu.Lock()
+	b := l.bucket("recipient", time.Now())
+	b.users++
+	//Fills the limiter with idle buckets so the next new key drops them
+	for i := 0; len(l.buckets) < maxIdleBuckets; i++ {
+		l.bucket(time.Duration(i).String(), time.Now())
+	}
+	l.bucket("new recipient", time.Now().Add(time.Hour))
+	kept := l.buckets["recipient"] == b
+	dropped := len(l.buckets) < maxIdleBuckets
+	l.mu.Unlock()
+
+	if !dropped {
+		t.Fatal("idle buckets were not dropped")
+	}
+	if !kept {
+		t.Error("bucket with a pending acquire was dropped")
+	}
+
+	release, err := l.acquire(context.Background(), "recipient")
+	if err != nil {
+		t.Fatalf("acquire() error = %v", err)
+	}
+	release()
+	l.mu.Lock()
+	users := b.users
+	l.mu.Unlock()
+	if users != 1 {
+		t.Errorf("users after acquire and release = %d, want 1 (the simulated pending call)", users)
+	}
+}
@@ -0,0 +1,79 @@
+package fblib
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/novatrixtech/go-fbmessenger/fbmodelsend"
+)
+
+//maxReceiptElements is the maximum number of items in a Receipt Template
+const maxReceiptElements = 100
+
+/*
+SendReceiptTemplateMessage - Sends an order receipt to a recipient on Facebook Messenger.
+The required fields of receipt are checked before calling Facebook.
+*/
+func (c *Client) SendReceiptTemplateMessage(ctx context.Context, receipt *fbmodelsend.ReceiptPayload, recipient string, msgType int, opts ...SendOption) (*fbmodelsend.SendResponse, error) {
+	if err := validateReceipt(receipt); err != nil {
+		return nil, err
+	}
+	receipt.TemplateType = fbmodelsend.TemplateTypeReceipt
+	return c.sendTemplate(ctx, receipt, recipient, msgType, opts...)
+}
+
+/*
+sendTemplate - Sends a template whose payload has its own structure to a recipient on Facebook Messenger
+*/
+func (c *Client) sendTemplate(ctx context.Context, payload interface{}, recipient string, msgType int, opts ...SendOption) (*fbmodelsend.SendResponse, error) {
+	msg := new(fbmodelsend.TemplateLetter)
+	msg.Recipient.ID = recipient
+	msg.MessageType = defineMessageType(msgType)
+	msg.Message.Attachment = &fbmodelsend.TemplateAttachment{
+		AttachmentType: fbmodelsend.AttachmentTypeTemplate,
+		Payload:        payload,
+	}
+	return c.sendMessage(ctx, recipient, msg, opts...)
+}
+
+//validateReceipt checks the required fields of a Receipt Template
+func validateReceipt(receipt *fbmodelsend.ReceiptPayload) error {
+	if receipt == nil {
+		return errors.New("[SendReceiptTemplateMessage] Receipt is required")
+	}
+
+	fields := new(requiredFields)
+	fields.require(receipt.RecipientName, "recipient_name")
+	fields.require(receipt.OrderNumber, "order_number")
+	fields.require(receipt.Currency, "currency")
+	fields.require(receipt.PaymentMethod, "payment_method")
+	if receipt.Address != nil {
+		fields.require(receipt.Address.Street1, "address.street_1")
+		fields.require(receipt.Address.City, "address.city")
+		fields.require(receipt.Address.PostalCode, "address.postal_code")
+		fields.require(receipt.Address.State, "address.state")
+		fields.require(receipt.Address.Country, "address.country")
+	}
+	for i, adjustment := range receipt.Adjustments {
+		if fields.requireEntry(adjustment == nil, fmt.Sprintf("adjustments[%d]", i)) {
+			fields.require(adjustment.Name, fmt.Sprintf("adjustments[%d].name", i))
+		}
+	}
+	for i, element := range receipt.Elements {
+		if fields.requireEntry(element == nil, fmt.Sprintf("elements[%d]", i)) {
+			fields.require(element.Title, fmt.Sprintf("elements[%d].title", i))
+		}
+	}
+	if err := fields.err("SendReceiptTemplateMessage", "Receipt"); err != nil {
+		return err
+	}
+
+	if receipt.Summary.TotalCost < 0 {
+		return errors.New("[SendReceiptTemplateMessage] Receipt summary total_cost can't be negative")
+	}
+	if len(receipt.Elements) > maxReceiptElements {
+		return fmt.Errorf("[SendReceiptTemplateMessage] Receipt supports up to %d elements", maxReceiptElements)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package fblib
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/novatrixtech/go-fbmessenger/fbmodelsend"
+)
+
+func TestValidateReceiptNilEntries(t *testing.T) {
+	receipt := &fbmodelsend.ReceiptPayload{
+		RecipientName: "Jane",
+		OrderNumber:   "1",
+		Currency:      "USD",
+		PaymentMethod: "Visa 1234",
+		Adjustments:   []*fbmodelsend.ReceiptAdjustment{nil, {}},
+		Elements:      []*fbmodelsend.ReceiptElement{{Title: "Shirt"}, nil},
+	}
+	err := validateReceipt(receipt)
+	if err == nil {
+		t.Fatal("validateReceipt() error = nil, want nil entries reported")
+	}
+	for _, want := range []string{"adjustments[0]", "adjustments[1].name", "elements[1]"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("validateReceipt() error = %q, want it to name %s", err, want)
+		}
+	}
+	if strings.Contains(err.Error(), "elements[0]") {
+		t.Errorf("validateReceipt() error = %q, elements[0] is valid", err)
+	}
+
+	receipt.Adjustments = nil
+	receipt.Elements = receipt.Elements[:1]
+	if err := validateReceipt(receipt); err != nil {
+		t.Errorf("validateReceipt() of a valid receipt error = %v", err)
+	}
+}
@@ -0,0 +1,165 @@
+package fblib
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+/*
+RetryPolicy defines how a Client retries transient failures of Graph API calls.
+Only network errors, 5xx responses, Graph API throttling (codes 4, 17, 32 and 613)
+and errors flagged as transient by Facebook are retried. Errors such as invalid
+recipient, policy violations, expired tokens or ErrRateLimited are returned right away.
+*/
+type RetryPolicy struct {
+	//MaxAttempts is the total number of attempts, including the first one. Less than 2 disables retries.
+	MaxAttempts int
+	//InitialBackoff is the wait before the first retry
+	InitialBackoff time.Duration
+	//MaxBackoff caps the wait between attempts. A Retry-After hint longer than it is not waited for and the error is returned.
+	MaxBackoff time.Duration
+	//Multiplier grows the wait after every attempt
+	Multiplier float64
+	//Jitter is the fraction, between 0 and 1, of the wait that is randomized
+	Jitter float64
+}
+
+//DefaultRetryPolicy is a sensible policy to be used with WithRetryPolicy
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     30 * time.Second,
+	Multiplier:     2,
+	Jitter:         0.2,
+}
+
+/*
+WithRetryPolicy enables automatic retries of transient failures. By default a Client doesn't retry.
+*/
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retryPolicy = policy
+	}
+}
+
+//backoff returns the wait before the given retry (1 for the first retry)
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	wait := float64(p.InitialBackoff) * math.Pow(multiplier, float64(retry-1))
+	if p.MaxBackoff > 0 && wait > float64(p.MaxBackoff) {
+		wait = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		jitter := math.Min(p.Jitter, 1)
+		wait = wait*(1-jitter) + wait*jitter*rand.Float64()
+	}
+	return time.Duration(wait)
+}
+
+//isRetryable tells whether a failed call may succeed if it is made again
+func isRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	//A fail fast limiter must fail fast, even when retries are enabled
+	if errors.Is(err, ErrRateLimited) {
+		return false
+	}
+	var graphErr *GraphError
+	if errors.As(err, &graphErr) {
+		return graphErr.StatusCode >= 500 || graphErr.IsRateLimited() || graphErr.IsTransient
+	}
+	//Only transport failures are retried, not local errors such as an invalid URL or a body that can't be encoded
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		err = urlErr.Err
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+/*
+retryCanceledError is returned when the context is done while waiting for a retry.
+It matches the context error through errors.Is and the last failure of the call through errors.As.
+*/
+type retryCanceledError struct {
+	ctxErr  error
+	lastErr error
+}
+
+//Error implements error interface
+func (e *retryCanceledError) Error() string {
+	return fmt.Sprintf("%s while waiting to retry: %s", e.ctxErr.Error(), e.lastErr.Error())
+}
+
+//Unwrap returns the context error
+func (e *retryCanceledError) Unwrap() error {
+	return e.ctxErr
+}
+
+//As lets errors.As find the last failure of the call, e.g. its *GraphError
+func (e *retryCanceledError) As(target interface{}) bool {
+	return errors.As(e.lastErr, target)
+}
+
+//retryAfter reads the Retry-After header, in seconds or as an HTTP date
+func retryAfter(header http.Header) time.Duration {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(date); wait > 0 {
+			return wait
+		}
+	}
+	return 0
+}
+
+/*
+withRetry runs call until it succeeds, returns a non retryable error or the policy gives up
+*/
+func (c *Client) withRetry(ctx context.Context, call func() error) error {
+	policy := c.retryPolicy
+	for attempt := 1; ; attempt++ {
+		err := call()
+		if err == nil || attempt >= policy.MaxAttempts || !isRetryable(err) {
+			return err
+		}
+
+		wait := policy.backoff(attempt)
+		var graphErr *GraphError
+		if errors.As(err, &graphErr) && graphErr.RetryAfter > wait {
+			if policy.MaxBackoff > 0 && graphErr.RetryAfter > policy.MaxBackoff {
+				return err
+			}
+			wait = graphErr.RetryAfter
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return &retryCanceledError{ctxErr: ctx.Err(), lastErr: err}
+		case <-timer.C:
+		}
+	}
+}
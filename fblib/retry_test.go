@@ -0,0 +1,194 @@
+package fblib
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"network error", &url.Error{Op: "Post", URL: "https://graph.facebook.com", Err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}}, true},
+		{"connection closed", &url.Error{Op: "Post", URL: "https://graph.facebook.com", Err: io.EOF}, true},
+		{"invalid URL", &url.Error{Op: "parse", URL: "://graph", Err: errors.New("missing protocol scheme")}, false},
+		{"local error", errors.New("json: unsupported value"), false},
+		{"context canceled", context.Canceled, false},
+		{"deadline exceeded", context.DeadlineExceeded, false},
+		{"client-side rate limit", ErrRateLimited, false},
+		{"server error", &GraphError{StatusCode: 503}, true},
+		{"throttled", &GraphError{StatusCode: 400, Code: 613}, true},
+		{"transient", &GraphError{StatusCode: 400, Code: 2, IsTransient: true}, true},
+		{"invalid recipient", &GraphError{StatusCode: 400, Code: 100, ErrorSubcode: 2018001}, false},
+		{"expired token", &GraphError{StatusCode: 401, Code: 190}, false},
+	}
+	for _, tt := range tests {
+		if got := isRetryable(tt.err); got != tt.want {
+			t.Errorf("%s: isRetryable() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     300 * time.Millisecond,
+		Multiplier:     2,
+	}
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond, 300 * time.Millisecond}
+	for i, w := range want {
+		if got := policy.backoff(i + 1); got != w {
+			t.Errorf("backoff(%d) = %v, want %v", i+1, got, w)
+		}
+	}
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if got := policy.backoff(1); got < 50*time.Millisecond || got > 100*time.Millisecond {
+			t.Fatalf("backoff(1) with jitter = %v, want between 50ms and 100ms", got)
+		}
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	header := http.Header{}
+	if got := retryAfter(header); got != 0 {
+		t.Errorf("retryAfter() without header = %v, want 0", got)
+	}
+	header.Set("Retry-After", "3")
+	if got := retryAfter(header); got != 3*time.Second {
+		t.Errorf("retryAfter() = %v, want 3s", got)
+	}
+	header.Set("Retry-After", "soon")
+	if got := retryAfter(header); got != 0 {
+		t.Errorf("retryAfter() with invalid header = %v, want 0", got)
+	}
+}
+
+//newRetryTestClient returns a Client, with fast retries, calling a server that answers with responses in order
+//(the last one is repeated) and the counter of calls it received
+func newRetryTestClient(t *testing.T, responses ...func(w http.ResponseWriter)) (*Client, *int32) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(&calls, 1))
+		if n > len(responses) {
+			n = len(responses)
+		}
+		responses[n-1](w)
+	}))
+	t.Cleanup(srv.Close)
+	c := NewClient("token", WithBaseURL(srv.URL), WithRetryPolicy(RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     10 * time.Millisecond,
+		Multiplier:     2,
+	}))
+	return c, &calls
+}
+
+func respond(status int, body string) func(w http.ResponseWriter) {
+	return func(w http.ResponseWriter) {
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}
+}
+
+func TestClientRetriesTransientFailures(t *testing.T) {
+	c, calls := newRetryTestClient(t,
+		respond(http.StatusInternalServerError, `{"error":{"message":"Service unavailable","code":2}}`),
+		respond(http.StatusBadRequest, `{"error":{"message":"Calls to this api have exceeded the rate limit","code":613}}`),
+		respond(http.StatusOK, `{"recipient_id":"1","message_id":"m"}`),
+	)
+	resp, err := c.SendTextMessage(context.Background(), "hi", "1", MessageTypeResponse)
+	if err != nil {
+		t.Fatalf("SendTextMessage() error = %v", err)
+	}
+	if resp.MessageID != "m" {
+		t.Errorf("MessageID = %q, want m", resp.MessageID)
+	}
+	if atomic.LoadInt32(calls) != 3 {
+		t.Errorf("calls = %d, want 3", atomic.LoadInt32(calls))
+	}
+}
+
+func TestClientDoesNotRetryPermanentErrors(t *testing.T) {
+	c, calls := newRetryTestClient(t,
+		respond(http.StatusBadRequest, `{"error":{"message":"No matching user found","code":100,"error_subcode":2018001}}`),
+	)
+	_, err := c.SendTextMessage(context.Background(), "hi", "1", MessageTypeResponse)
+	var graphErr *GraphError
+	if !errors.As(err, &graphErr) || !graphErr.IsInvalidRecipient() {
+		t.Fatalf("SendTextMessage() error = %v, want invalid recipient GraphError", err)
+	}
+	if !errors.Is(err, ErrInvalidCallToFacebook) {
+		t.Errorf("errors.Is(err, ErrInvalidCallToFacebook) = false")
+	}
+	if atomic.LoadInt32(calls) != 1 {
+		t.Errorf("calls = %d, want 1", atomic.LoadInt32(calls))
+	}
+}
+
+func TestClientGivesUpAfterMaxAttempts(t *testing.T) {
+	c, calls := newRetryTestClient(t, respond(http.StatusBadGateway, `bad gateway`))
+	_, err := c.SendTextMessage(context.Background(), "hi", "1", MessageTypeResponse)
+	var graphErr *GraphError
+	if !errors.As(err, &graphErr) || graphErr.StatusCode != http.StatusBadGateway || graphErr.Body != "bad gateway" {
+		t.Fatalf("SendTextMessage() error = %v, want 502 GraphError", err)
+	}
+	if atomic.LoadInt32(calls) != 3 {
+		t.Errorf("calls = %d, want 3", atomic.LoadInt32(calls))
+	}
+}
+
+func TestClientDoesNotWaitRetryAfterLongerThanMaxBackoff(t *testing.T) {
+	c, calls := newRetryTestClient(t, func(w http.ResponseWriter) {
+		w.Header().Set("Retry-After", "60")
+		respond(http.StatusServiceUnavailable, `{"error":{"message":"Try later","code":2}}`)(w)
+	})
+	start := time.Now()
+	_, err := c.SendTextMessage(context.Background(), "hi", "1", MessageTypeResponse)
+	if err == nil {
+		t.Fatal("SendTextMessage() error = nil, want GraphError")
+	}
+	if atomic.LoadInt32(calls) != 1 || time.Since(start) > time.Second {
+		t.Errorf("calls = %d in %v, want a single call returned right away", atomic.LoadInt32(calls), time.Since(start))
+	}
+}
+
+func TestClientRetryReturnsContextErrorWhileWaiting(t *testing.T) {
+	c, _ := newRetryTestClient(t, respond(http.StatusInternalServerError, `{"error":{"message":"Service unavailable","code":2}}`))
+	c.retryPolicy.InitialBackoff = time.Second
+	c.retryPolicy.MaxBackoff = time.Second
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err := c.SendTextMessage(ctx, "hi", "1", MessageTypeResponse)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("SendTextMessage() error = %v, want context.DeadlineExceeded", err)
+	}
+	var graphErr *GraphError
+	if !errors.As(err, &graphErr) || graphErr.StatusCode != http.StatusInternalServerError {
+		t.Errorf("errors.As(err, *GraphError) = %v, want the last 500 response", graphErr)
+	}
+}
+
+func TestClientDoesNotRetryInvalidURL(t *testing.T) {
+	c := NewClient("token", WithBaseURL("://graph"), WithRetryPolicy(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Second}))
+	start := time.Now()
+	if _, err := c.SendTextMessage(context.Background(), "hi", "1", MessageTypeResponse); err == nil {
+		t.Fatal("SendTextMessage() error = nil, want invalid URL error")
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("invalid URL took %v, it was retried", elapsed)
+	}
+}
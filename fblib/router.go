@@ -0,0 +1,244 @@
+package fblib
+
+import (
+	"context"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/novatrixtech/go-fbmessenger/fbmodelrecieve"
+)
+
+/*
+EventHandler handles one MessagingEvent
+*/
+type EventHandler func(ctx context.Context, event *fbmodelrecieve.MessagingEvent) error
+
+/*
+Middleware wraps an EventHandler, e.g. to log, recover or load the user session
+*/
+type Middleware func(next EventHandler) EventHandler
+
+/*
+Router dispatches every MessagingEvent to the first handler, in registration order, whose route matches it.
+Events that match no route go to the fallback handler.
+Standby events (Handover Protocol) only go to the standby handler.
+Register routes and middlewares before serving. HandleEvent and HandleStandbyEvent can be used
+as WebhookHandler.OnEvent and WebhookHandler.OnStandby:
+
+	router := fblib.NewRouter()
+	router.OnPostback("GET_STARTED", onGetStarted)
+	router.OnText(onText)
+	webhook.OnEvent = router.HandleEvent
+	webhook.OnStandby = router.HandleStandbyEvent
+*/
+type Router struct {
+	routes      []route
+	fallback    EventHandler
+	standby     EventHandler
+	middlewares []Middleware
+
+	//OnError receives the errors returned by handlers. By default they are logged.
+	OnError func(ctx context.Context, event *fbmodelrecieve.MessagingEvent, err error)
+}
+
+type route struct {
+	match   func(event *fbmodelrecieve.MessagingEvent) bool
+	handler EventHandler
+}
+
+/*
+NewRouter creates an empty Router
+*/
+func NewRouter() *Router {
+	return new(Router)
+}
+
+/*
+Use adds middlewares. The first one added is the outermost.
+*/
+func (r *Router) Use(middlewares ...Middleware) {
+	r.middlewares = append(r.middlewares, middlewares...)
+}
+
+/*
+Handle registers handler for the events accepted by match
+*/
+func (r *Router) Handle(match func(event *fbmodelrecieve.MessagingEvent) bool, handler EventHandler) {
+	r.routes = append(r.routes, route{match: match, handler: handler})
+}
+
+/*
+OnText handles text messages sent by the user that are not quick replies
+*/
+func (r *Router) OnText(handler EventHandler) {
+	r.Handle(func(event *fbmodelrecieve.MessagingEvent) bool {
+		return event.Kind() == fbmodelrecieve.EventKindMessage && event.Message.QuickReply == nil && event.Message.Text != ""
+	}, handler)
+}
+
+/*
+OnPostback handles postbacks whose payload starts with payloadPrefix. An empty prefix matches every postback.
+*/
+func (r *Router) OnPostback(payloadPrefix string, handler EventHandler) {
+	r.Handle(func(event *fbmodelrecieve.MessagingEvent) bool {
+		return event.Postback != nil && strings.HasPrefix(event.Postback.Payload, payloadPrefix)
+	}, handler)
+}
+
+/*
+OnQuickReply handles quick replies whose payload matches the regular expression pattern.
+It panics if pattern doesn't compile.
+*/
+func (r *Router) OnQuickReply(pattern string, handler EventHandler) {
+	re := regexp.MustCompile(pattern)
+	r.Handle(func(event *fbmodelrecieve.MessagingEvent) bool {
+		return event.Kind() == fbmodelrecieve.EventKindMessage && event.Message.QuickReply != nil && re.MatchString(event.Message.QuickReply.Payload)
+	}, handler)
+}
+
+/*
+OnAttachment handles messages sent by the user with an attachment of attachmentType (image, audio, video, file, fallback...).
+An empty attachmentType matches every attachment.
+*/
+func (r *Router) OnAttachment(attachmentType string, handler EventHandler) {
+	r.Handle(func(event *fbmodelrecieve.MessagingEvent) bool {
+		if event.Kind() != fbmodelrecieve.EventKindMessage {
+			return false
+		}
+		for _, attch := range event.Message.Attachments {
+			if attachmentType == "" || attch.Type == attachmentType {
+				return true
+			}
+		}
+		return false
+	}, handler)
+}
+
+/*
+OnLocation handles locations shared by the user
+*/
+func (r *Router) OnLocation(handler EventHandler) {
+	r.OnAttachment("location", handler)
+}
+
+/*
+OnRead handles read events
+*/
+func (r *Router) OnRead(handler EventHandler) {
+	r.onKind(fbmodelrecieve.EventKindRead, handler)
+}
+
+/*
+OnDelivery handles delivery events
+*/
+func (r *Router) OnDelivery(handler EventHandler) {
+	r.onKind(fbmodelrecieve.EventKindDelivery, handler)
+}
+
+/*
+OnReferral handles referral events
+*/
+func (r *Router) OnReferral(handler EventHandler) {
+	r.onKind(fbmodelrecieve.EventKindReferral, handler)
+}
+
+/*
+OnEcho handles the echoes of messages sent by the Page
+*/
+func (r *Router) OnEcho(handler EventHandler) {
+	r.onKind(fbmodelrecieve.EventKindEcho, handler)
+}
+
+/*
+Fallback handles the events that match no route
+*/
+func (r *Router) Fallback(handler EventHandler) {
+	r.fallback = handler
+}
+
+/*
+OnPassThreadControl handles the control of conversations being passed to the app
+*/
+func (r *Router) OnPassThreadControl(handler EventHandler) {
+	r.onKind(fbmodelrecieve.EventKindPassThreadControl, handler)
+}
+
+/*
+OnTakeThreadControl handles the Primary Receiver taking the control of conversations from the app
+*/
+func (r *Router) OnTakeThreadControl(handler EventHandler) {
+	r.onKind(fbmodelrecieve.EventKindTakeThreadControl, handler)
+}
+
+/*
+OnRequestThreadControl handles Secondary Receivers asking the app for the control of conversations
+*/
+func (r *Router) OnRequestThreadControl(handler EventHandler) {
+	r.onKind(fbmodelrecieve.EventKindRequestThreadControl, handler)
+}
+
+/*
+OnStandby handles the events of conversations controlled by another app
+*/
+func (r *Router) OnStandby(handler EventHandler) {
+	r.standby = handler
+}
+
+func (r *Router) onKind(kind fbmodelrecieve.EventKind, handler EventHandler) {
+	r.Handle(func(event *fbmodelrecieve.MessagingEvent) bool {
+		return event.Kind() == kind
+	}, handler)
+}
+
+/*
+Dispatch handles every event of payload, in order
+*/
+func (r *Router) Dispatch(ctx context.Context, payload *fbmodelrecieve.FacebookMessageRecieved) {
+	for i := range payload.Entry {
+		for j := range payload.Entry[i].Messaging {
+			r.HandleEvent(ctx, &payload.Entry[i].Messaging[j])
+		}
+		for j := range payload.Entry[i].Standby {
+			r.HandleStandbyEvent(ctx, &payload.Entry[i].Standby[j])
+		}
+	}
+}
+
+/*
+HandleStandbyEvent runs, through the middlewares, the standby handler
+*/
+func (r *Router) HandleStandbyEvent(ctx context.Context, event *fbmodelrecieve.MessagingEvent) {
+	r.run(ctx, r.standby, event)
+}
+
+/*
+HandleEvent runs, through the middlewares, the handler of the first route matching event
+*/
+func (r *Router) HandleEvent(ctx context.Context, event *fbmodelrecieve.MessagingEvent) {
+	handler := r.fallback
+	for _, rt := range r.routes {
+		if rt.match(event) {
+			handler = rt.handler
+			break
+		}
+	}
+	r.run(ctx, handler, event)
+}
+
+//run calls handler through the middlewares reporting its error
+func (r *Router) run(ctx context.Context, handler EventHandler, event *fbmodelrecieve.MessagingEvent) {
+	if handler == nil {
+		return
+	}
+	for i := len(r.middlewares) - 1; i >= 0; i-- {
+		handler = r.middlewares[i](handler)
+	}
+	if err := handler(ctx, event); err != nil {
+		if r.OnError != nil {
+			r.OnError(ctx, event, err)
+			return
+		}
+		log.Printf("[fblib][Router] Error handling [%s] event from [%s]: %s\n", event.Kind(), event.Sender.ID, err.Error())
+	}
+}
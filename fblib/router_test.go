@@ -0,0 +1,179 @@
+package fblib
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/novatrixtech/go-fbmessenger/fbmodelrecieve"
+)
+
+//record returns a handler that appends name to calls
+func record(calls *[]string, name string) EventHandler {
+	return func(ctx context.Context, event *fbmodelrecieve.MessagingEvent) error {
+		*calls = append(*calls, name)
+		return nil
+	}
+}
+
+func textEvent(text string) *fbmodelrecieve.MessagingEvent {
+	return &fbmodelrecieve.MessagingEvent{Message: &fbmodelrecieve.MessageEvent{Text: text}}
+}
+
+func quickReplyEvent(payload string) *fbmodelrecieve.MessagingEvent {
+	return &fbmodelrecieve.MessagingEvent{Message: &fbmodelrecieve.MessageEvent{
+		Text:       "tap",
+		QuickReply: &fbmodelrecieve.QuickReply{Payload: payload},
+	}}
+}
+
+func postbackEvent(payload string) *fbmodelrecieve.MessagingEvent {
+	return &fbmodelrecieve.MessagingEvent{Postback: &fbmodelrecieve.PostbackEvent{Payload: payload}}
+}
+
+func attachmentEvent(attachmentType string) *fbmodelrecieve.MessagingEvent {
+	return &fbmodelrecieve.MessagingEvent{Message: &fbmodelrecieve.MessageEvent{
+		Attachments: []fbmodelrecieve.Attachment{{Type: attachmentType}},
+	}}
+}
+
+func TestRouterRoutes(t *testing.T) {
+	var calls []string
+	router := NewRouter()
+	router.OnPostback("ORDER_", record(&calls, "order"))
+	router.OnPostback("", record(&calls, "postback"))
+	router.OnQuickReply("^SIZE_(S|M|L)$", record(&calls, "size"))
+	router.OnText(record(&calls, "text"))
+	router.OnLocation(record(&calls, "location"))
+	router.OnAttachment("", record(&calls, "attachment"))
+	router.OnRead(record(&calls, "read"))
+	router.Fallback(record(&calls, "fallback"))
+
+	tests := []struct {
+		event *fbmodelrecieve.MessagingEvent
+		want  string
+	}{
+		{postbackEvent("ORDER_42"), "order"},
+		{postbackEvent("GET_STARTED"), "postback"},
+		{quickReplyEvent("SIZE_M"), "size"},
+		//Quick replies not matched by OnQuickReply are not text messages
+		{quickReplyEvent("SIZE_XL"), "fallback"},
+		{textEvent("hi"), "text"},
+		{attachmentEvent("location"), "location"},
+		{attachmentEvent("image"), "attachment"},
+		{&fbmodelrecieve.MessagingEvent{Read: &fbmodelrecieve.ReadEvent{}}, "read"},
+		{&fbmodelrecieve.MessagingEvent{Delivery: &fbmodelrecieve.DeliveryEvent{}}, "fallback"},
+		{&fbmodelrecieve.MessagingEvent{Message: &fbmodelrecieve.MessageEvent{Text: "echo", IsEcho: true}}, "fallback"},
+	}
+	for _, tt := range tests {
+		calls = nil
+		router.HandleEvent(context.Background(), tt.event)
+		if len(calls) != 1 || calls[0] != tt.want {
+			t.Errorf("%s event went to %v, want [%s]", tt.event.Kind(), calls, tt.want)
+		}
+	}
+}
+
+func TestRouterFirstMatchWins(t *testing.T) {
+	var calls []string
+	router := NewRouter()
+	router.OnText(record(&calls, "first"))
+	router.OnText(record(&calls, "second"))
+	router.HandleEvent(context.Background(), textEvent("hi"))
+	if len(calls) != 1 || calls[0] != "first" {
+		t.Errorf("calls = %v, want [first]", calls)
+	}
+}
+
+func TestRouterWithoutFallbackIgnoresUnmatchedEvents(t *testing.T) {
+	var calls []string
+	router := NewRouter()
+	router.OnText(record(&calls, "text"))
+	router.HandleEvent(context.Background(), postbackEvent("GET_STARTED"))
+	if len(calls) != 0 {
+		t.Errorf("calls = %v, want none", calls)
+	}
+}
+
+func TestRouterMiddlewaresOrder(t *testing.T) {
+	var calls []string
+	middleware := func(name string) Middleware {
+		return func(next EventHandler) EventHandler {
+			return func(ctx context.Context, event *fbmodelrecieve.MessagingEvent) error {
+				calls = append(calls, name+" before")
+				err := next(ctx, event)
+				calls = append(calls, name+" after")
+				return err
+			}
+		}
+	}
+	router := NewRouter()
+	router.Use(middleware("outer"), middleware("inner"))
+	router.OnText(record(&calls, "handler"))
+	router.OnStandby(record(&calls, "standby"))
+
+	router.HandleEvent(context.Background(), textEvent("hi"))
+	want := "outer before,inner before,handler,inner after,outer after"
+	if got := strings.Join(calls, ","); got != want {
+		t.Errorf("calls = %s, want %s", got, want)
+	}
+
+	calls = nil
+	router.HandleStandbyEvent(context.Background(), textEvent("hi"))
+	want = "outer before,inner before,standby,inner after,outer after"
+	if got := strings.Join(calls, ","); got != want {
+		t.Errorf("standby calls = %s, want %s", got, want)
+	}
+}
+
+func TestRouterDispatchStandby(t *testing.T) {
+	var calls []string
+	router := NewRouter()
+	router.OnText(record(&calls, "text"))
+	router.OnStandby(record(&calls, "standby"))
+	router.Dispatch(context.Background(), &fbmodelrecieve.FacebookMessageRecieved{
+		Entry: []fbmodelrecieve.Entry{{
+			Messaging: []fbmodelrecieve.MessagingEvent{*textEvent("hi")},
+			Standby:   []fbmodelrecieve.MessagingEvent{*textEvent("to the other app")},
+		}},
+	})
+	if got := strings.Join(calls, ","); got != "text,standby" {
+		t.Errorf("calls = %s, want text,standby", got)
+	}
+}
+
+func TestRouterErrors(t *testing.T) {
+	errHandler := errors.New("handler failed")
+	router := NewRouter()
+	router.OnText(func(ctx context.Context, event *fbmodelrecieve.MessagingEvent) error {
+		return errHandler
+	})
+
+	var logged bytes.Buffer
+	log.SetOutput(&logged)
+	defer log.SetOutput(os.Stderr)
+
+	event := textEvent("hi")
+	event.Sender.ID = "42"
+	router.HandleEvent(context.Background(), event)
+	if !strings.Contains(logged.String(), "handler failed") || !strings.Contains(logged.String(), "[42]") {
+		t.Errorf("log = %q, want the error and the sender", logged.String())
+	}
+
+	logged.Reset()
+	var reported error
+	router.OnError = func(ctx context.Context, event *fbmodelrecieve.MessagingEvent, err error) {
+		reported = err
+	}
+	router.HandleEvent(context.Background(), event)
+	if reported != errHandler {
+		t.Errorf("OnError received %v, want %v", reported, errHandler)
+	}
+	if logged.Len() != 0 {
+		t.Errorf("log = %q, want nothing when OnError is set", logged.String())
+	}
+}
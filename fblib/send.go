@@ -1,24 +1,16 @@
 package fblib
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"errors"
-	"fmt"
-	"io/ioutil"
-	"net/http"
-	"strings"
-	"time"
-
-	"github.com/davecgh/go-spew/spew"
 
 	"github.com/novatrixtech/go-fbmessenger/fbmodelsend"
 )
 
 var logLevelDebug = false
 
-//ErrInvalidCallToFacebook is specific error when Facebook Messenger returns error after being called
-var ErrInvalidCallToFacebook = errors.New("")
+//ErrLocationQuickReplyRemoved is returned by SendAskUserLocation since Messenger doesn't support location quick replies anymore
+var ErrLocationQuickReplyRemoved = errors.New("fblib: location quick replies were removed from Messenger")
 
 //MessageTypeResponse is in response to a received message.
 const MessageTypeResponse = 1
@@ -29,6 +21,47 @@ const MessageTypeUpdate = 2
 //MessageTypeMessageTag is non-promotional and is being sent outside the 24-hour standard messaging window with a message tag.
 const MessageTypeMessageTag = 3
 
+/*
+SendOption changes a message before it is sent, e.g. WithPersona
+*/
+type SendOption func(*sendOptions)
+
+//sendOptions holds the send options given to a send function
+type sendOptions struct {
+	personaID string
+}
+
+/*
+WithPersona sends the message, or the sender action, as the Persona personaID instead of as the Page
+*/
+func WithPersona(personaID string) SendOption {
+	return func(o *sendOptions) {
+		o.personaID = personaID
+	}
+}
+
+//applySendOptions sets the send options on the messages that support them
+func applySendOptions(message interface{}, opts []SendOption) {
+	if len(opts) == 0 {
+		return
+	}
+	o := new(sendOptions)
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.personaID == "" {
+		return
+	}
+	switch m := message.(type) {
+	case *fbmodelsend.Letter:
+		m.PersonaID = o.personaID
+	case *fbmodelsend.TemplateLetter:
+		m.PersonaID = o.personaID
+	case *fbmodelsend.SenderAction:
+		m.PersonaID = o.personaID
+	}
+}
+
 //defineMessageType returns the Message Type description defined by Messenger
 func defineMessageType(msgType int) (msgTypeDescription string) {
 	switch msgType {
@@ -42,16 +75,23 @@ func defineMessageType(msgType int) (msgTypeDescription string) {
 	return
 }
 
+/*
+SendLetter - Sends a complete message, e.g. one made with fbmodelsend.LetterBuilder, to its recipient on Facebook Messenger
+*/
+func (c *Client) SendLetter(ctx context.Context, letter *fbmodelsend.Letter, opts ...SendOption) (*fbmodelsend.SendResponse, error) {
+	return c.sendMessage(ctx, letter.Recipient.ID, letter, opts...)
+}
+
 /*
 SendTextMessage - Send text message to a recipient on Facebook Messenger
 */
-func SendTextMessage(text string, recipient string, accessToken string, msgType int) (err error) {
+func (c *Client) SendTextMessage(ctx context.Context, text string, recipient string, msgType int, opts ...SendOption) (resp *fbmodelsend.SendResponse, err error) {
 	err = nil
 	letter := new(fbmodelsend.Letter)
 	letter.Message.Text = text
 	letter.Recipient.ID = recipient
 	letter.MessageType = defineMessageType(msgType)
-	err = sendMessage(letter, recipient, accessToken)
+	resp, err = c.sendMessage(ctx, recipient, letter, opts...)
 	if err != nil {
 		//fmt.Print("[fblib][sendTextMessage] Error during the call to Facebook to send the text message: " + err.Error())
 		return
@@ -60,14 +100,14 @@ func SendTextMessage(text string, recipient string, accessToken string, msgType
 }
 
 //SendPersonalFinanceUpdateMessage sends a Finance Update information to recipient
-func SendPersonalFinanceUpdateMessage(text string, recipient string, accessToken string) (err error) {
+func (c *Client) SendPersonalFinanceUpdateMessage(ctx context.Context, text string, recipient string, opts ...SendOption) (resp *fbmodelsend.SendResponse, err error) {
 	err = nil
 	letter := new(fbmodelsend.Letter)
 	letter.Message.Text = text
 	letter.Tag = "PERSONAL_FINANCE_UPDATE"
 	letter.Recipient.ID = recipient
 	letter.MessageType = defineMessageType(3)
-	err = sendMessage(letter, recipient, accessToken)
+	resp, err = c.sendMessage(ctx, recipient, letter, opts...)
 	if err != nil {
 		//fmt.Print("[fblib][sendTextMessage] Error during the call to Facebook to send the text message: " + err.Error())
 		return
@@ -75,76 +115,25 @@ func SendPersonalFinanceUpdateMessage(text string, recipient string, accessToken
 	return
 }
 
-/*
-SendImageMessage - Sends image message to a recipient on Facebook Messenger
-*/
-func SendImageMessage(url string, recipient string, accessToken string, msgType int) (err error) {
-	err = nil
-	message := new(fbmodelsend.Letter)
-	message.MessageType = defineMessageType(msgType)
-
-	attch := new(fbmodelsend.Attachment)
-	attch.AttachmentType = "image"
-	attch.Payload.URL = url
-	message.Message.Attachment = attch
-
-	message.Recipient.ID = recipient
-	err = sendMessage(message, recipient, accessToken)
-	if err != nil {
-		fmt.Print("[fblib][sendImageMessage] Error during the call to Facebook to send the image message: " + err.Error())
-		return
-	}
-	return
-}
-
-/*
-SendAudioMessage - Sends audio message to a recipient on Facebook Messenger
-*/
-func SendAudioMessage(url string, recipient string, accessToken string, msgType int) (err error) {
-	err = nil
-	message := new(fbmodelsend.Letter)
-	message.MessageType = defineMessageType(msgType)
-	attch := new(fbmodelsend.Attachment)
-	attch.AttachmentType = "audio"
-	attch.Payload.URL = url
-	message.Message.Attachment = attch
-
-	message.Recipient.ID = recipient
-	err = sendMessage(message, recipient, accessToken)
-	if err != nil {
-		//fmt.Print("[fblib][sendImageMessage] Error during the call to Facebook to send the audio message: " + err.Error())
-		return
-	}
-	return
-}
-
 /*
 SendTypingMessage - Sends typing message to user
 */
-func SendTypingMessage(onoff bool, recipient string, accessToken string, msgType int) (err error) {
-	err = nil
-	senderAction := new(fbmodelsend.SenderAction)
-	senderAction.MessageType = defineMessageType(msgType)
-	senderAction.Recipient.ID = recipient
+func (c *Client) SendTypingMessage(ctx context.Context, onoff bool, recipient string, msgType int, opts ...SendOption) (*fbmodelsend.SendResponse, error) {
 	if onoff {
-		senderAction.SenderActionState = "typing_on"
-	} else {
-		senderAction.SenderActionState = "typing_off"
-	}
-	err = sendMessage(senderAction, recipient, accessToken)
-	if err != nil {
-		//fmt.Print("[fblib][sendImageMessage] Error during the call to Facebook to send the typing message: " + err.Error())
-		return
+		return c.SendSenderAction(ctx, fbmodelsend.SenderActionTypingOn, recipient, msgType, opts...)
 	}
-	return
+	return c.SendSenderAction(ctx, fbmodelsend.SenderActionTypingOff, recipient, msgType, opts...)
 }
 
 /*
 SendGenericTemplateMessage - Sends a generic rich message to Facebook user.
 It can include text, buttons, URLs Butttons, lists to reply
 */
-func SendGenericTemplateMessage(template []*fbmodelsend.TemplateElement, recipient string, accessToken string, msgType int) (err error) {
-	err = nil
+func (c *Client) SendGenericTemplateMessage(ctx context.Context, template []*fbmodelsend.TemplateElement, recipient string, msgType int, opts ...SendOption) (resp *fbmodelsend.SendResponse, err error) {
+	err = validateTemplateElements(template)
+	if err != nil {
+		return
+	}
 	msg := new(fbmodelsend.Letter)
 	msg.Recipient.ID = recipient
 	msg.MessageType = defineMessageType(msgType)
@@ -155,7 +144,7 @@ func SendGenericTemplateMessage(template []*fbmodelsend.TemplateElement, recipie
 
 	msg.Message.Attachment = attch
 
-	err = sendMessage(msg, recipient, accessToken)
+	resp, err = c.sendMessage(ctx, recipient, msg, opts...)
 	if err != nil {
 		//fmt.Print("[fblib][SendGenericTemplateMessage] Error during the call to Facebook to send the text message: " + err.Error())
 		return
@@ -167,8 +156,11 @@ func SendGenericTemplateMessage(template []*fbmodelsend.TemplateElement, recipie
 SendButtonMessage - Sends a generic rich message to Facebook user.
 It can include text, buttons, URLs Butttons, lists to reply
 */
-func SendButtonMessage(template []*fbmodelsend.Button, text string, recipient string, accessToken string, msgType int) (err error) {
-	err = nil
+func (c *Client) SendButtonMessage(ctx context.Context, template []*fbmodelsend.Button, text string, recipient string, msgType int, opts ...SendOption) (resp *fbmodelsend.SendResponse, err error) {
+	err = validateButtons(template)
+	if err != nil {
+		return
+	}
 	msg := new(fbmodelsend.Letter)
 	msg.Recipient.ID = recipient
 	msg.MessageType = defineMessageType(msgType)
@@ -181,7 +173,7 @@ func SendButtonMessage(template []*fbmodelsend.Button, text string, recipient st
 
 	msg.Message.Attachment = attch
 
-	err = sendMessage(msg, recipient, accessToken)
+	resp, err = c.sendMessage(ctx, recipient, msg, opts...)
 	if err != nil {
 		//fmt.Print("[fblib][sendTextMessage] Error during the call to Facebook to send the text message: " + err.Error())
 		return
@@ -192,22 +184,17 @@ func SendButtonMessage(template []*fbmodelsend.Button, text string, recipient st
 /*
 SendURLButtonMessage - Sends a message with a button that redirects the user to an external web page.
 */
-func SendURLButtonMessage(text string, buttonTitle string, URL string, recipient string, accessToken string, msgType int) (err error) {
+func (c *Client) SendURLButtonMessage(ctx context.Context, text string, buttonTitle string, URL string, recipient string, msgType int, opts ...SendOption) (resp *fbmodelsend.SendResponse, err error) {
 	err = nil
 	msgElement := new(fbmodelsend.TemplateElement)
 	msgElement.Title = text
 
-	opt1 := new(fbmodelsend.Button)
-	opt1.ButtonType = "web_url"
-	opt1.Title = buttonTitle
-	opt1.URL = URL
-
-	buttons := []*fbmodelsend.Button{opt1}
+	buttons := []*fbmodelsend.Button{fbmodelsend.NewURLButton(buttonTitle, URL)}
 
 	msgElement.Buttons = buttons
 	elements := []*fbmodelsend.TemplateElement{msgElement}
 
-	err = SendGenericTemplateMessage(elements, recipient, accessToken, msgType)
+	resp, err = c.SendGenericTemplateMessage(ctx, elements, recipient, msgType, opts...)
 	if err != nil {
 		//fmt.Print("[fblib][SendURLButtonMessage] Error during the call to Facebook to send the text message: " + err.Error())
 		return
@@ -215,11 +202,10 @@ func SendURLButtonMessage(text string, buttonTitle string, URL string, recipient
 	return
 }
 
-
 /*
 SendQuickReply sends small messages in order to get small and quick answers from the users
 */
-func SendQuickReply(text string, options []*fbmodelsend.QuickReply, recipient string, accessToken string, msgType int) (err error) {
+func (c *Client) SendQuickReply(ctx context.Context, text string, options []*fbmodelsend.QuickReply, recipient string, msgType int, opts ...SendOption) (resp *fbmodelsend.SendResponse, err error) {
 	err = nil
 	msg := new(fbmodelsend.Letter)
 	msg.MessageType = defineMessageType(msgType)
@@ -227,7 +213,7 @@ func SendQuickReply(text string, options []*fbmodelsend.QuickReply, recipient st
 	msg.Message.Text = text
 	msg.Message.QuickReplies = options
 	//log.Printf("[SendQuickReply] Enviado: [%s]\n", text)
-	err = sendMessage(msg, recipient, accessToken)
+	resp, err = c.sendMessage(ctx, recipient, msg, opts...)
 	if err != nil {
 		//log.Print("[fblib][SendQuickReply] Error during the call to Facebook to send the text message: " + err.Error())
 		return
@@ -236,83 +222,127 @@ func SendQuickReply(text string, options []*fbmodelsend.QuickReply, recipient st
 }
 
 /*
-SendAskUserLocation sends small message asking the users their location
+SendAskUserLocation used to send a location quick reply. Messenger removed them and rejects the message,
+so it returns ErrLocationQuickReplyRemoved without calling Facebook.
+
+Deprecated: Messenger doesn't support location quick replies anymore
 */
-func SendAskUserLocation(text string, recipient string, accessToken string, msgType int) (err error) {
-	err = nil
-	qr := new(fbmodelsend.QuickReply)
-	qr.ContentType = "location"
+func (c *Client) SendAskUserLocation(ctx context.Context, text string, recipient string, msgType int, opts ...SendOption) (*fbmodelsend.SendResponse, error) {
+	return nil, ErrLocationQuickReplyRemoved
+}
 
-	arrayQr := []*fbmodelsend.QuickReply{qr}
+/*
+SendAskUserPhoneNumber sends small message with a quick reply offering the phone number of the user profile.
+The phone number comes back in the quick reply payload, see fbmodelrecieve.QuickReply.PhoneNumber
+*/
+func (c *Client) SendAskUserPhoneNumber(ctx context.Context, text string, recipient string, msgType int, opts ...SendOption) (*fbmodelsend.SendResponse, error) {
+	return c.SendQuickReply(ctx, text, []*fbmodelsend.QuickReply{fbmodelsend.NewUserPhoneNumberQuickReply()}, recipient, msgType, opts...)
+}
 
-	err = SendQuickReply(text, arrayQr, recipient, accessToken, msgType)
-	if err != nil {
-		//log.Print("[fblib][SendAskUserLocation] Error during the call to Facebook to send the text message: " + err.Error())
-		return
-	}
+/*
+SendAskUserEmail sends small message with a quick reply offering the email of the user profile.
+The email comes back in the quick reply payload, see fbmodelrecieve.QuickReply.Email
+*/
+func (c *Client) SendAskUserEmail(ctx context.Context, text string, recipient string, msgType int, opts ...SendOption) (*fbmodelsend.SendResponse, error) {
+	return c.SendQuickReply(ctx, text, []*fbmodelsend.QuickReply{fbmodelsend.NewUserEmailQuickReply()}, recipient, msgType, opts...)
+}
+
+/*
+SendTextMessage - Send text message to a recipient on Facebook Messenger
+
+Deprecated: use Client.SendTextMessage
+*/
+func SendTextMessage(text string, recipient string, accessToken string, msgType int, opts ...SendOption) (err error) {
+	_, err = legacyClient(accessToken).SendTextMessage(context.Background(), text, recipient, msgType, opts...)
 	return
 }
 
 /*
-Send Message - Sends a generic message to Facebook Messenger
+SendPersonalFinanceUpdateMessage sends a Finance Update information to recipient
+
+Deprecated: use Client.SendPersonalFinanceUpdateMessage
 */
-func sendMessage(message interface{}, recipient string, accessToken string) error {
+func SendPersonalFinanceUpdateMessage(text string, recipient string, accessToken string, opts ...SendOption) (err error) {
+	_, err = legacyClient(accessToken).SendPersonalFinanceUpdateMessage(context.Background(), text, recipient, opts...)
+	return
+}
 
-	if logLevelDebug {
-		scs := spew.ConfigState{Indent: "\t"}
-		scs.Dump(message)
-		return nil
-	}
+/*
+SendImageMessage - Sends image message to a recipient on Facebook Messenger
 
-	var url string
-	if strings.Contains(accessToken, "http") {
-		url = accessToken
-	} else {
-		url = "https://graph.facebook.com/v6.0/me/messages?access_token=" + accessToken
-	}
+Deprecated: use Client.SendImageMessage
+*/
+func SendImageMessage(url string, recipient string, accessToken string, msgType int, opts ...SendOption) (err error) {
+	_, err = legacyClient(accessToken).SendImageMessage(context.Background(), url, recipient, msgType, opts...)
+	return
+}
 
-	data, err := json.Marshal(message)
-	if err != nil {
-		//fmt.Print("[fblib][sendMessage] Error to convert message object: " + err.Error())
-		return err
-	}
+/*
+SendAudioMessage - Sends audio message to a recipient on Facebook Messenger
 
-	reqFb, _ := http.NewRequest("POST", url, bytes.NewBuffer(data))
-	reqFb.Header.Set("Content-Type", "application/json")
-	reqFb.Header.Set("Connection", "close")
-	reqFb.Close = true
+Deprecated: use Client.SendAudioMessage
+*/
+func SendAudioMessage(url string, recipient string, accessToken string, msgType int, opts ...SendOption) (err error) {
+	_, err = legacyClient(accessToken).SendAudioMessage(context.Background(), url, recipient, msgType, opts...)
+	return
+}
 
-	client := &http.Client{
-		Timeout: time.Second * 30,
-	}
+/*
+SendTypingMessage - Sends typing message to user
+
+Deprecated: use Client.SendTypingMessage
+*/
+func SendTypingMessage(onoff bool, recipient string, accessToken string, msgType int, opts ...SendOption) (err error) {
+	_, err = legacyClient(accessToken).SendTypingMessage(context.Background(), onoff, recipient, msgType, opts...)
+	return
+}
 
-	//fmt.Println("[sendMessage] Replying at: " + url + " the message " + string(data))
+/*
+SendGenericTemplateMessage - Sends a generic rich message to Facebook user.
 
-	respFb, err := client.Do(reqFb)
-	if err != nil {
-		//fmt.Print("[fblib][sendMessage] Error during the call to Facebook to send the message: " + err.Error())
-		return err
-	}
-	defer respFb.Body.Close()
-
-	if respFb.StatusCode < 200 || respFb.StatusCode >= 300 {
-		bodyFromFb, _ := ioutil.ReadAll(respFb.Body)
-		status := string(bodyFromFb)
-		fmt.Printf("[fblib][sendMessage] Response status code: [%d]\n", respFb.StatusCode)
-		fmt.Println("[fblib][sendMessage] Response status: ", respFb.Status)
-		fmt.Println("[fblib][sendMessage] Response Body from Facebook: ", status)
-		fmt.Printf("[fblib][sendMessage] Facebook URL Called: [%s]\n", url)
-		fmt.Printf("[fblib][sendMessage] Object sent to Facebook: [%s]\n", string(data))
-		strErr := fmt.Sprintf("[fblib][sendMessage] Response status code: [%d]\nResponse status: [%s]\nResponse Body from Facebook: [%s]\nFacebook URL Called: [%s]\nObject sent to Facebook: [%s]\n",
-			respFb.StatusCode,
-			respFb.Status,
-			status,
-			url,
-			string(data),
-		)
-		ErrInvalidCallToFacebook = errors.New(strErr)
-		return ErrInvalidCallToFacebook
-	}
+Deprecated: use Client.SendGenericTemplateMessage
+*/
+func SendGenericTemplateMessage(template []*fbmodelsend.TemplateElement, recipient string, accessToken string, msgType int, opts ...SendOption) (err error) {
+	_, err = legacyClient(accessToken).SendGenericTemplateMessage(context.Background(), template, recipient, msgType, opts...)
+	return
+}
+
+/*
+SendButtonMessage - Sends a generic rich message to Facebook user.
+
+Deprecated: use Client.SendButtonMessage
+*/
+func SendButtonMessage(template []*fbmodelsend.Button, text string, recipient string, accessToken string, msgType int, opts ...SendOption) (err error) {
+	_, err = legacyClient(accessToken).SendButtonMessage(context.Background(), template, text, recipient, msgType, opts...)
+	return
+}
+
+/*
+SendURLButtonMessage - Sends a message with a button that redirects the user to an external web page.
+
+Deprecated: use Client.SendURLButtonMessage
+*/
+func SendURLButtonMessage(text string, buttonTitle string, URL string, recipient string, accessToken string, msgType int, opts ...SendOption) (err error) {
+	_, err = legacyClient(accessToken).SendURLButtonMessage(context.Background(), text, buttonTitle, URL, recipient, msgType, opts...)
+	return
+}
+
+/*
+SendQuickReply sends small messages in order to get small and quick answers from the users
 
-	return nil
+Deprecated: use Client.SendQuickReply
+*/
+func SendQuickReply(text string, options []*fbmodelsend.QuickReply, recipient string, accessToken string, msgType int, opts ...SendOption) (err error) {
+	_, err = legacyClient(accessToken).SendQuickReply(context.Background(), text, options, recipient, msgType, opts...)
+	return
+}
+
+/*
+SendAskUserLocation used to send a location quick reply. It always returns ErrLocationQuickReplyRemoved.
+
+Deprecated: Messenger doesn't support location quick replies anymore. Use Client.SendAskUserPhoneNumber or Client.SendAskUserEmail to ask for contact data
+*/
+func SendAskUserLocation(text string, recipient string, accessToken string, msgType int, opts ...SendOption) (err error) {
+	_, err = legacyClient(accessToken).SendAskUserLocation(context.Background(), text, recipient, msgType, opts...)
+	return
 }
@@ -0,0 +1,60 @@
+package fblib
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/novatrixtech/go-fbmessenger/fbmodelsend"
+)
+
+func TestSendGenericTemplateMessageValidatesElements(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Write([]byte(`{"recipient_id":"1","message_id":"m"}`))
+	}))
+	defer srv.Close()
+	c := NewClient("token", WithBaseURL(srv.URL))
+	ctx := context.Background()
+
+	invalid := map[string][]*fbmodelsend.TemplateElement{
+		"nil element": {nil},
+		"nil button":  {{Title: "Shirt", Buttons: []*fbmodelsend.Button{nil}}},
+		"bad button":  {{Title: "Shirt", Buttons: []*fbmodelsend.Button{{ButtonType: "bad", Title: "Buy"}}}},
+	}
+	for name, elements := range invalid {
+		if _, err := c.SendGenericTemplateMessage(ctx, elements, "1", MessageTypeResponse); err == nil {
+			t.Errorf("%s: SendGenericTemplateMessage() error = nil, want validation error", name)
+		}
+	}
+	if n := atomic.LoadInt32(&calls); n != 0 {
+		t.Fatalf("invalid templates were sent %d times", n)
+	}
+
+	valid := []*fbmodelsend.TemplateElement{{
+		Title:   "Shirt",
+		Buttons: []*fbmodelsend.Button{fbmodelsend.NewPostbackButton("Buy", "BUY")},
+	}}
+	if _, err := c.SendGenericTemplateMessage(ctx, valid, "1", MessageTypeResponse); err != nil {
+		t.Errorf("SendGenericTemplateMessage() error = %v", err)
+	}
+}
+
+func TestSendAskUserLocationDoesNotCallFacebook(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+	}))
+	defer srv.Close()
+	c := NewClient("token", WithBaseURL(srv.URL))
+
+	if _, err := c.SendAskUserLocation(context.Background(), "Where are you?", "1", MessageTypeResponse); err != ErrLocationQuickReplyRemoved {
+		t.Errorf("SendAskUserLocation() error = %v, want ErrLocationQuickReplyRemoved", err)
+	}
+	if n := atomic.LoadInt32(&calls); n != 0 {
+		t.Errorf("calls = %d, want 0", n)
+	}
+}
@@ -0,0 +1,116 @@
+package fblib
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/novatrixtech/go-fbmessenger/fbmodelrecieve"
+	"github.com/novatrixtech/go-fbmessenger/fbmodelsend"
+)
+
+//typingRefreshInterval is how often WhileTyping sends typing_on again. Messenger hides the indicator after about 20 seconds.
+const typingRefreshInterval = 15 * time.Second
+
+//typingOffTimeout bounds the typing_off sent by WhileTyping, which doesn't use the context of fn
+const typingOffTimeout = 5 * time.Second
+
+/*
+SendSenderAction - Sends a sender action (fbmodelsend.SenderActionMarkSeen, SenderActionTypingOn or SenderActionTypingOff) to recipient
+*/
+func (c *Client) SendSenderAction(ctx context.Context, action string, recipient string, msgType int, opts ...SendOption) (*fbmodelsend.SendResponse, error) {
+	if !fbmodelsend.IsValidSenderAction(action) {
+		return nil, errors.New("[SendSenderAction] Invalid sender action [" + action + "]")
+	}
+	return c.sendMessage(ctx, recipient, newSenderAction(action, recipient, msgType), opts...)
+}
+
+//newSenderAction creates the sender action request of action to recipient
+func newSenderAction(action string, recipient string, msgType int) *fbmodelsend.SenderAction {
+	senderAction := new(fbmodelsend.SenderAction)
+	senderAction.MessageType = defineMessageType(msgType)
+	senderAction.Recipient.ID = recipient
+	senderAction.SenderActionState = action
+	return senderAction
+}
+
+/*
+sendTypingIndicator sends the typing action of WhileTyping. It skips the recipient rate limiter: holding the turn
+of recipient would fail, or delay, the reply sent by fn meanwhile. The page rate limiter still applies.
+Failures are ignored.
+*/
+func (c *Client) sendTypingIndicator(ctx context.Context, action string, recipient string, msgType int, opts []SendOption) {
+	senderAction := newSenderAction(action, recipient, msgType)
+	applySendOptions(senderAction, opts)
+	if logLevelDebug {
+		return
+	}
+	c.postMessage(ctx, senderAction)
+}
+
+/*
+SendMarkSeen - Marks the last message sent by recipient as read
+*/
+func (c *Client) SendMarkSeen(ctx context.Context, recipient string, msgType int, opts ...SendOption) (*fbmodelsend.SendResponse, error) {
+	return c.SendSenderAction(ctx, fbmodelsend.SenderActionMarkSeen, recipient, msgType, opts...)
+}
+
+/*
+WhileTyping - Shows the typing indicator to recipient while fn runs, usually building and sending the reply.
+The indicator is sent again every 15 seconds so it doesn't disappear during long calls and it is turned off when fn returns,
+even when ctx was canceled meanwhile.
+Failures to send the indicator are ignored, they never stop fn. The error returned is the one of fn.
+The indicator doesn't go through the recipient rate limiter, so it never competes with the reply for the turn of recipient.
+*/
+func (c *Client) WhileTyping(ctx context.Context, recipient string, msgType int, fn func(ctx context.Context) error, opts ...SendOption) error {
+	c.sendTypingIndicator(ctx, fbmodelsend.SenderActionTypingOn, recipient, msgType, opts)
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(typingRefreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				c.sendTypingIndicator(ctx, fbmodelsend.SenderActionTypingOn, recipient, msgType, opts)
+			case <-stop:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	//The refresh is stopped, and waited for, before turning the indicator off so a late typing_on can't show it again.
+	//typing_off has its own context: the indicator must be turned off even when ctx is canceled or past its deadline.
+	defer func() {
+		close(stop)
+		<-done
+		offCtx, cancel := context.WithTimeout(context.Background(), typingOffTimeout)
+		defer cancel()
+		c.sendTypingIndicator(offCtx, fbmodelsend.SenderActionTypingOff, recipient, msgType, opts)
+	}()
+
+	return fn(ctx)
+}
+
+/*
+TypingIndicator returns a Router Middleware that shows the typing indicator, with WhileTyping, while the handlers
+of messages and postbacks run. Other events, such as deliveries and reads, don't show it.
+*/
+func TypingIndicator(c *Client, opts ...SendOption) Middleware {
+	return func(next EventHandler) EventHandler {
+		return func(ctx context.Context, event *fbmodelrecieve.MessagingEvent) error {
+			switch event.Kind() {
+			case fbmodelrecieve.EventKindMessage, fbmodelrecieve.EventKindPostback:
+			default:
+				return next(ctx, event)
+			}
+			return c.WhileTyping(ctx, event.Sender.ID, MessageTypeResponse, func(ctx context.Context) error {
+				return next(ctx, event)
+			}, opts...)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package fblib
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/novatrixtech/go-fbmessenger/fbmodelsend"
+)
+
+//newSenderActionTestClient returns a Client whose Send API calls are recorded, in order, as the sender action or "message"
+func newSenderActionTestClient(t *testing.T) (*Client, func() []string) {
+	var mu sync.Mutex
+	var actions []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			SenderAction string `json:"sender_action"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		if body.SenderAction == "" {
+			body.SenderAction = "message"
+		}
+		actions = append(actions, body.SenderAction)
+		mu.Unlock()
+		w.Write([]byte(`{"recipient_id":"1"}`))
+	}))
+	t.Cleanup(srv.Close)
+	return NewClient("token", WithBaseURL(srv.URL)), func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), actions...)
+	}
+}
+
+func TestSendSenderActionRejectsUnknownAction(t *testing.T) {
+	c, actions := newSenderActionTestClient(t)
+	if _, err := c.SendSenderAction(context.Background(), "typing", "1", MessageTypeResponse); err == nil {
+		t.Error("SendSenderAction() with unknown action error = nil, want error")
+	}
+	if _, err := c.SendMarkSeen(context.Background(), "1", MessageTypeResponse); err != nil {
+		t.Errorf("SendMarkSeen() error = %v", err)
+	}
+	if got := actions(); len(got) != 1 || got[0] != fbmodelsend.SenderActionMarkSeen {
+		t.Errorf("sent %v, want [mark_seen]", got)
+	}
+}
+
+func TestWhileTyping(t *testing.T) {
+	c, actions := newSenderActionTestClient(t)
+	errReply := errors.New("reply failed")
+	err := c.WhileTyping(context.Background(), "1", MessageTypeResponse, func(ctx context.Context) error {
+		c.SendTextMessage(ctx, "hi", "1", MessageTypeResponse)
+		return errReply
+	})
+	if err != errReply {
+		t.Errorf("WhileTyping() error = %v, want the error of fn", err)
+	}
+	want := []string{fbmodelsend.SenderActionTypingOn, "message", fbmodelsend.SenderActionTypingOff}
+	if got := actions(); len(got) != len(want) || got[0] != want[0] || got[1] != want[1] || got[2] != want[2] {
+		t.Errorf("sent %v, want %v", got, want)
+	}
+}
+
+func TestWhileTypingTurnsOffAfterCancel(t *testing.T) {
+	c, actions := newSenderActionTestClient(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	c.WhileTyping(ctx, "1", MessageTypeResponse, func(ctx context.Context) error {
+		cancel()
+		return ctx.Err()
+	})
+	got := actions()
+	if len(got) == 0 || got[len(got)-1] != fbmodelsend.SenderActionTypingOff {
+		t.Errorf("sent %v, want typing_off last", got)
+	}
+}
+
+func TestWhileTypingSkipsRecipientRateLimiter(t *testing.T) {
+	c, actions := newSenderActionTestClient(t)
+	//One message per recipient at a time and no tokens left after the first: only the reply may take them
+	WithRecipientRateLimiter(NewRateLimiter(0.001, 1, RateLimitFailFast))(c)
+	err := c.WhileTyping(context.Background(), "1", MessageTypeResponse, func(ctx context.Context) error {
+		_, err := c.SendTextMessage(ctx, "hi", "1", MessageTypeResponse)
+		return err
+	})
+	if err != nil {
+		t.Errorf("WhileTyping() reply error = %v, want the reply sent", err)
+	}
+	want := []string{fbmodelsend.SenderActionTypingOn, "message", fbmodelsend.SenderActionTypingOff}
+	if got := actions(); len(got) != len(want) || got[0] != want[0] || got[1] != want[1] || got[2] != want[2] {
+		t.Errorf("sent %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,68 @@
+package fblib
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/novatrixtech/go-fbmessenger/fbmodelsend"
+)
+
+//attachmentsPath is the Attachment Upload API endpoint relative to the Graph API version
+const attachmentsPath = "me/message_attachments"
+
+/*
+UploadAttachmentURL - Uploads the asset at url to Facebook and returns its reusable attachment ID.
+Facebook fetches the asset only once and the attachment ID can be sent to any recipient.
+*/
+func (c *Client) UploadAttachmentURL(ctx context.Context, attachmentType string, url string) (attachmentID string, err error) {
+	upload := new(fbmodelsend.AttachmentUpload)
+	attch := new(fbmodelsend.Attachment)
+	attch.AttachmentType = attachmentType
+	attch.Payload.URL = url
+	attch.Payload.IsReusable = true
+	upload.Message.Attachment = attch
+
+	resp := new(fbmodelsend.SendResponse)
+	if err = c.doGraphRequest(ctx, http.MethodPost, attachmentsPath, nil, upload, resp); err != nil {
+		return "", err
+	}
+	return uploadedAttachmentID(resp)
+}
+
+/*
+UploadAttachment - Uploads file to Facebook and returns its reusable attachment ID.
+file is streamed to Facebook as multipart/form-data, so private and locally generated assets can be sent.
+*/
+func (c *Client) UploadAttachment(ctx context.Context, attachmentType string, fileName string, mimeType string, file io.Reader) (attachmentID string, err error) {
+	upload := new(fbmodelsend.AttachmentUpload)
+	attch := new(fbmodelsend.Attachment)
+	attch.AttachmentType = attachmentType
+	attch.Payload.IsReusable = true
+	upload.Message.Attachment = attch
+
+	message, err := json.Marshal(upload.Message)
+	if err != nil {
+		return "", err
+	}
+
+	body := multipartBody(
+		[]formField{{name: "message", value: string(message)}},
+		formFile{fieldName: "filedata", fileName: fileName, mimeType: mimeType, content: file},
+	)
+	resp := new(fbmodelsend.SendResponse)
+	if err = c.call(ctx, http.MethodPost, attachmentsPath, nil, body, false, resp); err != nil {
+		return "", err
+	}
+	return uploadedAttachmentID(resp)
+}
+
+//uploadedAttachmentID returns the attachment ID of an upload response
+func uploadedAttachmentID(resp *fbmodelsend.SendResponse) (string, error) {
+	if resp.AttachmentID == "" {
+		return "", errors.New("[fblib][uploadedAttachmentID] Facebook didn't return the attachment_id")
+	}
+	return resp.AttachmentID, nil
+}
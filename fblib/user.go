@@ -1,11 +1,9 @@
 package fblib
 
 import (
-	"encoding/json"
-	"errors"
-	"fmt"
-	"io/ioutil"
+	"context"
 	"net/http"
+	"net/url"
 
 	"github.com/novatrixtech/go-fbmessenger/fbmodelsend"
 )
@@ -14,45 +12,24 @@ import (
 GetUserData - Get Facebook User's data.
 It can be obtained after she starts a conversation with Bot
 */
-func GetUserData(senderID string, accessToken string) (*fbmodelsend.User, error) {
-
-	url := fmt.Sprintf("https://graph.facebook.com/v6.0/%s?fields=first_name,last_name,profile_pic&access_token=%s",
-		senderID,
-		accessToken)
-	//fmt.Println("[GetUserData] URL: " + url)
-
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	client := &http.Client{}
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-
-	defer resp.Body.Close()
-
-	data, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	//respBody := string(data)
-	//fmt.Println("[GetUserData] Response: " + respBody)
-	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		err = errors.New("go-fbmessenger->GetUserData Error: " + string(data))
-		return nil, err
-	}
+func (c *Client) GetUserData(ctx context.Context, senderID string) (*fbmodelsend.User, error) {
+	query := url.Values{}
+	query.Set("fields", "first_name,last_name,profile_pic")
 
 	fbUser := new(fbmodelsend.User)
-
-	if err := json.Unmarshal(data, fbUser); err != nil {
-		fmt.Println("[GetUserData] Erro no unmarshall " + err.Error())
+	if err := c.doGraphRequest(ctx, http.MethodGet, senderID, query, nil, fbUser); err != nil {
 		return nil, err
 	}
 
 	return fbUser, nil
 }
+
+/*
+GetUserData - Get Facebook User's data.
+It can be obtained after she starts a conversation with Bot
+
+Deprecated: use Client.GetUserData
+*/
+func GetUserData(senderID string, accessToken string) (*fbmodelsend.User, error) {
+	return NewClient(accessToken).GetUserData(context.Background(), senderID)
+}
@@ -0,0 +1,80 @@
+package fblib
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/novatrixtech/go-fbmessenger/fbmodelsend"
+)
+
+/*
+requiredFields collects the names of the required fields that are empty and of the list entries that are nil
+*/
+type requiredFields struct {
+	missing    []string
+	nilEntries []string
+}
+
+//require records field as missing when value is blank
+func (r *requiredFields) require(value string, field string) {
+	if strings.TrimSpace(value) == "" {
+		r.missing = append(r.missing, field)
+	}
+}
+
+//requireSet records field as missing when set is false
+func (r *requiredFields) requireSet(set bool, field string) {
+	if !set {
+		r.missing = append(r.missing, field)
+	}
+}
+
+//requireEntry records the list entry field as nil when isNil is true. It tells whether the entry can be checked further.
+func (r *requiredFields) requireEntry(isNil bool, field string) bool {
+	if isNil {
+		r.nilEntries = append(r.nilEntries, field)
+	}
+	return !isNil
+}
+
+//err returns an error naming every missing field and nil entry, or nil when there is none
+func (r *requiredFields) err(caller string, what string) error {
+	var problems []string
+	if len(r.missing) > 0 {
+		problems = append(problems, "without required fields: "+strings.Join(r.missing, ", "))
+	}
+	if len(r.nilEntries) > 0 {
+		problems = append(problems, "with nil entries: "+strings.Join(r.nilEntries, ", "))
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("[%s] %s %s", caller, what, strings.Join(problems, "; "))
+}
+
+//validateButtons checks every button before it's sent to Facebook
+func validateButtons(buttons []*fbmodelsend.Button) error {
+	for _, btn := range buttons {
+		if btn == nil {
+			return errors.New("[validateButtons] Nil button")
+		}
+		if err := btn.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//validateTemplateElements checks every generic template element, and its buttons, before it's sent to Facebook
+func validateTemplateElements(elements []*fbmodelsend.TemplateElement) error {
+	for _, elem := range elements {
+		if elem == nil {
+			return errors.New("[validateTemplateElements] Nil template element")
+		}
+		if err := validateButtons(elem.Buttons); err != nil {
+			return err
+		}
+	}
+	return nil
+}
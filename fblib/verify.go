@@ -1,19 +1,92 @@
 package fblib
 
 import (
+	"bytes"
 	"crypto/hmac"
 	"crypto/sha1"
-	"fmt"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"io/ioutil"
+	"net/http"
+	"strings"
 )
 
+//maxWebhookBodySize is the largest Webhook request body read before its signature is checked
+const maxWebhookBodySize = 4 << 20
+
 /*
-VerifySignature it verifies Fb Messenger's message signature to avoid spams and DDoS attacks
+VerifySignature it verifies Fb Messenger's message signature to avoid spams and DDoS attacks.
+expectedSignature is the HMAC-SHA1 hex digest, with or without the sha1= prefix.
+It is always false when appSecret is empty.
 */
 func VerifySignature(appSecret string, bytes []byte, expectedSignature string) bool {
-	mac := hmac.New(sha1.New, []byte(appSecret))
-	mac.Write(bytes)
-	if fmt.Sprintf("%x", mac.Sum(nil)) != expectedSignature {
+	if !strings.HasPrefix(expectedSignature, "sha1=") {
+		expectedSignature = "sha1=" + expectedSignature
+	}
+	return VerifySignatureHeader(appSecret, bytes, expectedSignature)
+}
+
+/*
+VerifySignatureHeader verifies the full value of X-Hub-Signature (sha1=...) or X-Hub-Signature-256 (sha256=...)
+against the raw request body. It is always false when appSecret is empty.
+*/
+func VerifySignatureHeader(appSecret string, body []byte, signature string) bool {
+	switch {
+	case strings.HasPrefix(signature, "sha256="):
+		return validHMAC(sha256.New, appSecret, body, strings.TrimPrefix(signature, "sha256="))
+	case strings.HasPrefix(signature, "sha1="):
+		return validHMAC(sha1.New, appSecret, body, strings.TrimPrefix(signature, "sha1="))
+	}
+	return false
+}
+
+/*
+VerifyRequestSignature verifies the signature headers sent by Facebook against the raw request body.
+X-Hub-Signature-256 is preferred. X-Hub-Signature is only used when the former is absent.
+It is always false when appSecret is empty, since anyone can sign with an empty key.
+*/
+func VerifyRequestSignature(appSecret string, body []byte, header http.Header) bool {
+	if signature := header.Get("X-Hub-Signature-256"); signature != "" {
+		return VerifySignatureHeader(appSecret, body, signature)
+	}
+	if signature := header.Get("X-Hub-Signature"); signature != "" {
+		return VerifySignatureHeader(appSecret, body, signature)
+	}
+	return false
+}
+
+/*
+RequireSignature is a middleware that rejects with 403 the requests that are unsigned or whose
+signature doesn't match the body. next receives the request with its body intact.
+Every request is rejected when appSecret is empty.
+*/
+func RequireSignature(appSecret string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBodySize))
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+		if !VerifyRequestSignature(appSecret, body, r.Header) {
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+		next.ServeHTTP(w, r)
+	})
+}
+
+//validHMAC compares in constant time the HMAC of body with the hex encoded signature
+func validHMAC(h func() hash.Hash, appSecret string, body []byte, signature string) bool {
+	if appSecret == "" {
+		return false
+	}
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
 		return false
 	}
-	return true
+	mac := hmac.New(h, []byte(appSecret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
 }
@@ -0,0 +1,127 @@
+package fblib
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func sign1(appSecret string, body string) string {
+	mac := hmac.New(sha1.New, []byte(appSecret))
+	mac.Write([]byte(body))
+	return "sha1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifySignatureHeader(t *testing.T) {
+	body := `{"object":"page"}`
+	tests := []struct {
+		name      string
+		appSecret string
+		signature string
+		want      bool
+	}{
+		{"sha256", "secret", sign256("secret", body), true},
+		{"sha1", "secret", sign1("secret", body), true},
+		{"sha256 with other secret", "secret", sign256("other", body), false},
+		{"sha1 with other secret", "secret", sign1("other", body), false},
+		{"without prefix", "secret", strings.TrimPrefix(sign256("secret", body), "sha256="), false},
+		{"unknown algorithm", "secret", "md5=" + strings.TrimPrefix(sign256("secret", body), "sha256="), false},
+		{"invalid hex", "secret", "sha256=zz", false},
+		{"empty", "secret", "", false},
+		{"empty app secret", "", sign256("", body), false},
+		{"empty app secret sha1", "", sign1("", body), false},
+	}
+	for _, tt := range tests {
+		if got := VerifySignatureHeader(tt.appSecret, []byte(body), tt.signature); got != tt.want {
+			t.Errorf("%s: VerifySignatureHeader() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	body := `{"object":"page"}`
+	signature := sign1("secret", body)
+	if !VerifySignature("secret", []byte(body), signature) {
+		t.Error("VerifySignature() with sha1= prefix = false, want true")
+	}
+	if !VerifySignature("secret", []byte(body), strings.TrimPrefix(signature, "sha1=")) {
+		t.Error("VerifySignature() without prefix = false, want true")
+	}
+	if VerifySignature("", []byte(body), sign1("", body)) {
+		t.Error("VerifySignature() with empty app secret = true, want false")
+	}
+}
+
+func TestVerifyRequestSignature(t *testing.T) {
+	body := `{"object":"page"}`
+
+	header := http.Header{}
+	header.Set("X-Hub-Signature", sign1("secret", body))
+	if !VerifyRequestSignature("secret", []byte(body), header) {
+		t.Error("only X-Hub-Signature = false, want true")
+	}
+
+	//X-Hub-Signature-256 wins over a valid X-Hub-Signature
+	header.Set("X-Hub-Signature-256", sign256("other", body))
+	if VerifyRequestSignature("secret", []byte(body), header) {
+		t.Error("invalid X-Hub-Signature-256 with valid X-Hub-Signature = true, want false")
+	}
+	header.Set("X-Hub-Signature-256", sign256("secret", body))
+	if !VerifyRequestSignature("secret", []byte(body), header) {
+		t.Error("valid X-Hub-Signature-256 = false, want true")
+	}
+
+	if VerifyRequestSignature("secret", []byte(body), http.Header{}) {
+		t.Error("unsigned request = true, want false")
+	}
+
+	header = http.Header{}
+	header.Set("X-Hub-Signature-256", sign256("", body))
+	if VerifyRequestSignature("", []byte(body), header) {
+		t.Error("empty app secret = true, want false")
+	}
+}
+
+func TestRequireSignature(t *testing.T) {
+	body := `{"object":"page"}`
+	var received string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		received = string(b)
+	})
+
+	tests := []struct {
+		name      string
+		appSecret string
+		signature string
+		code      int
+	}{
+		{"signed", "secret", sign256("secret", body), http.StatusOK},
+		{"wrong signature", "secret", sign256("other", body), http.StatusForbidden},
+		{"unsigned", "secret", "", http.StatusForbidden},
+		{"empty app secret", "", sign256("", body), http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		received = ""
+		req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+		if tt.signature != "" {
+			req.Header.Set("X-Hub-Signature-256", tt.signature)
+		}
+		rec := httptest.NewRecorder()
+		RequireSignature(tt.appSecret, next).ServeHTTP(rec, req)
+		if rec.Code != tt.code {
+			t.Errorf("%s: status = %d, want %d", tt.name, rec.Code, tt.code)
+		}
+		if tt.code == http.StatusOK && received != body {
+			t.Errorf("%s: next received body %q, want %q", tt.name, received, body)
+		}
+		if tt.code != http.StatusOK && received != "" {
+			t.Errorf("%s: next was called", tt.name)
+		}
+	}
+}
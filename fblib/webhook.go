@@ -0,0 +1,128 @@
+package fblib
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net/http"
+
+	"github.com/novatrixtech/go-fbmessenger/fbmodelrecieve"
+)
+
+/*
+WebhookHandler is a http.Handler for the Messenger Webhook.
+It answers the subscription challenge (GET) using VerifyToken and, for events (POST),
+checks the request signature with AppSecret, decodes the payload and hands it to OnPayload
+and then each of its events, in order, to OnEvent. Standby events, those of conversations
+controlled by another app through the Handover Protocol, go to OnStandby.
+Facebook gets its 200 right away: the handlers run in their own goroutine, so they must not rely on
+the request context.
+More details at https://developers.facebook.com/docs/messenger-platform/webhook
+*/
+type WebhookHandler struct {
+	VerifyToken string
+	//AppSecret is used to check X-Hub-Signature-256 or X-Hub-Signature. Without it every event is rejected, unless SkipSignatureCheck is set.
+	AppSecret string
+	//SkipSignatureCheck accepts events without checking their signature. Only meant for local development and tests.
+	SkipSignatureCheck bool
+
+	OnPayload func(ctx context.Context, payload *fbmodelrecieve.FacebookMessageRecieved)
+	OnEvent   func(ctx context.Context, event *fbmodelrecieve.MessagingEvent)
+	OnStandby func(ctx context.Context, event *fbmodelrecieve.MessagingEvent)
+}
+
+/*
+NewWebhookHandler creates a WebhookHandler
+*/
+func NewWebhookHandler(verifyToken string, appSecret string, onPayload func(ctx context.Context, payload *fbmodelrecieve.FacebookMessageRecieved)) *WebhookHandler {
+	return &WebhookHandler{
+		VerifyToken: verifyToken,
+		AppSecret:   appSecret,
+		OnPayload:   onPayload,
+	}
+}
+
+//ServeHTTP implements http.Handler interface
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.verifySubscription(w, r)
+	case http.MethodPost:
+		h.receiveEvents(w, r)
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
+
+//verifySubscription answers the challenge sent by Facebook when the Webhook is subscribed
+func (h *WebhookHandler) verifySubscription(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("hub.mode") != "subscribe" || h.VerifyToken == "" || query.Get("hub.verify_token") != h.VerifyToken {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(query.Get("hub.challenge")))
+}
+
+//receiveEvents validates and decodes the events sent by Facebook
+func (h *WebhookHandler) receiveEvents(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBodySize))
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	if !h.SkipSignatureCheck && (h.AppSecret == "" || !VerifyRequestSignature(h.AppSecret, body, r.Header)) {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+
+	payload := new(fbmodelrecieve.FacebookMessageRecieved)
+	if err := json.Unmarshal(body, payload); err != nil {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("EVENT_RECEIVED"))
+
+	if h.OnPayload != nil || h.OnEvent != nil || h.OnStandby != nil {
+		go h.dispatch(payload)
+	}
+}
+
+//dispatch hands the payload to OnPayload and its events to OnEvent and OnStandby
+func (h *WebhookHandler) dispatch(payload *fbmodelrecieve.FacebookMessageRecieved) {
+	ctx := context.Background()
+	if h.OnPayload != nil {
+		safely(func() { h.OnPayload(ctx, payload) })
+	}
+	for i := range payload.Entry {
+		if h.OnEvent != nil {
+			for j := range payload.Entry[i].Messaging {
+				event := &payload.Entry[i].Messaging[j]
+				safely(func() { h.OnEvent(ctx, event) })
+			}
+		}
+		if h.OnStandby != nil {
+			for j := range payload.Entry[i].Standby {
+				event := &payload.Entry[i].Standby[j]
+				safely(func() { h.OnStandby(ctx, event) })
+			}
+		}
+	}
+}
+
+//safely runs fn preventing a panic there to bring the server down
+func safely(fn func()) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			log.Printf("[fblib][WebhookHandler] Panic handling the payload: %v\n", rec)
+		}
+	}()
+	fn()
+}
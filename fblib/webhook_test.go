@@ -0,0 +1,101 @@
+package fblib
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/novatrixtech/go-fbmessenger/fbmodelrecieve"
+)
+
+const testWebhookBody = `{"object":"page","entry":[{"id":"1","time":1,"messaging":[{"sender":{"id":"u"},"recipient":{"id":"1"},"message":{"mid":"m","text":"hi"}}]}]}`
+
+func sign256(appSecret string, body string) string {
+	mac := hmac.New(sha256.New, []byte(appSecret))
+	mac.Write([]byte(body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+//postEvents posts body to h with the given signature and returns the status code and the events dispatched
+func postEvents(h *WebhookHandler, body string, signature string) (int, []string) {
+	events := make(chan string, 10)
+	h.OnEvent = func(ctx context.Context, event *fbmodelrecieve.MessagingEvent) {
+		events <- event.Message.Text
+	}
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+	if signature != "" {
+		req.Header.Set("X-Hub-Signature-256", signature)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	var received []string
+	if rec.Code == http.StatusOK {
+		select {
+		case text := <-events:
+			received = append(received, text)
+		case <-time.After(time.Second):
+		}
+	}
+	return rec.Code, received
+}
+
+func TestWebhookHandlerChecksSignature(t *testing.T) {
+	h := NewWebhookHandler("verify", "secret", nil)
+	if code, events := postEvents(h, testWebhookBody, sign256("secret", testWebhookBody)); code != http.StatusOK || len(events) != 1 || events[0] != "hi" {
+		t.Errorf("signed POST = %d %v, want 200 [hi]", code, events)
+	}
+	if code, _ := postEvents(h, testWebhookBody, sign256("other", testWebhookBody)); code != http.StatusForbidden {
+		t.Errorf("POST with wrong signature = %d, want 403", code)
+	}
+	if code, _ := postEvents(h, testWebhookBody, ""); code != http.StatusForbidden {
+		t.Errorf("unsigned POST = %d, want 403", code)
+	}
+}
+
+func TestWebhookHandlerWithoutAppSecret(t *testing.T) {
+	h := NewWebhookHandler("verify", "", nil)
+	if code, _ := postEvents(h, testWebhookBody, sign256("", testWebhookBody)); code != http.StatusForbidden {
+		t.Errorf("POST without AppSecret = %d, want 403", code)
+	}
+	h.SkipSignatureCheck = true
+	if code, events := postEvents(h, testWebhookBody, ""); code != http.StatusOK || len(events) != 1 {
+		t.Errorf("POST with SkipSignatureCheck = %d %v, want 200 [hi]", code, events)
+	}
+}
+
+func TestWebhookHandlerRejectsLargeBody(t *testing.T) {
+	h := NewWebhookHandler("verify", "secret", nil)
+	body := strings.Repeat(" ", maxWebhookBodySize) + testWebhookBody
+	if code, _ := postEvents(h, body, sign256("secret", body)); code != http.StatusBadRequest {
+		t.Errorf("POST over the size limit = %d, want 400", code)
+	}
+}
+
+func TestWebhookHandlerVerifySubscription(t *testing.T) {
+	h := NewWebhookHandler("verify", "secret", nil)
+	tests := []struct {
+		query string
+		code  int
+	}{
+		{"hub.mode=subscribe&hub.verify_token=verify&hub.challenge=42", http.StatusOK},
+		{"hub.mode=subscribe&hub.verify_token=wrong&hub.challenge=42", http.StatusForbidden},
+		{"hub.mode=unsubscribe&hub.verify_token=verify&hub.challenge=42", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/webhook?"+tt.query, nil))
+		if rec.Code != tt.code {
+			t.Errorf("GET %s = %d, want %d", tt.query, rec.Code, tt.code)
+		}
+		if tt.code == http.StatusOK && rec.Body.String() != "42" {
+			t.Errorf("GET %s body = %q, want the challenge", tt.query, rec.Body.String())
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package fbmodelrecieve
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+//EventKind identifies which event a MessagingEvent carries
+type EventKind string
+
+//EventKindMessage is a message sent by the user
+const EventKindMessage EventKind = "message"
+
+//EventKindEcho is a message sent by the Page, echoed back to the Webhook
+const EventKindEcho EventKind = "echo"
+
+//EventKindPostback is a tap on a postback button, Get Started button or persistent menu item
+const EventKindPostback EventKind = "postback"
+
+//EventKindDelivery tells that messages sent by the Page were delivered
+const EventKindDelivery EventKind = "delivery"
+
+//EventKindRead tells that messages sent by the Page were read
+const EventKindRead EventKind = "read"
+
+//EventKindReferral is a user coming through a m.me link, ad or chat plugin into an existing conversation
+const EventKindReferral EventKind = "referral"
+
+//EventKindPassThreadControl tells that the control of the conversation was passed to the app
+const EventKindPassThreadControl EventKind = "pass_thread_control"
+
+//EventKindTakeThreadControl tells that the Primary Receiver took the control of the conversation from the app
+const EventKindTakeThreadControl EventKind = "take_thread_control"
+
+//EventKindRequestThreadControl tells that a Secondary Receiver asked the app for the control of the conversation
+const EventKindRequestThreadControl EventKind = "request_thread_control"
+
+//EventKindUnknown is an event not modeled by this package
+const EventKindUnknown EventKind = "unknown"
+
+/*
+Participant - Sender or recipient of an event, identified by its Page-scoped ID (PSID) or Page ID
+*/
+type Participant struct {
+	ID string `json:"id"`
+}
+
+/*
+MessagingEvent - One event received from Messenger. Only the field of its Kind is set.
+*/
+type MessagingEvent struct {
+	Sender    Participant    `json:"sender"`
+	Recipient Participant    `json:"recipient"`
+	Timestamp int64          `json:"timestamp"`
+	Message   *MessageEvent  `json:"message,omitempty"`
+	Postback  *PostbackEvent `json:"postback,omitempty"`
+	Delivery  *DeliveryEvent `json:"delivery,omitempty"`
+	Read      *ReadEvent     `json:"read,omitempty"`
+	Referral  *ReferralEvent `json:"referral,omitempty"`
+
+	PassThreadControl    *PassThreadControlEvent    `json:"pass_thread_control,omitempty"`
+	TakeThreadControl    *TakeThreadControlEvent    `json:"take_thread_control,omitempty"`
+	RequestThreadControl *RequestThreadControlEvent `json:"request_thread_control,omitempty"`
+}
+
+/*
+Kind returns which event is carried by MessagingEvent
+*/
+func (e *MessagingEvent) Kind() EventKind {
+	switch {
+	case e.Message != nil && e.Message.IsEcho:
+		return EventKindEcho
+	case e.Message != nil:
+		return EventKindMessage
+	case e.Postback != nil:
+		return EventKindPostback
+	case e.Delivery != nil:
+		return EventKindDelivery
+	case e.Read != nil:
+		return EventKindRead
+	case e.Referral != nil:
+		return EventKindReferral
+	case e.PassThreadControl != nil:
+		return EventKindPassThreadControl
+	case e.TakeThreadControl != nil:
+		return EventKindTakeThreadControl
+	case e.RequestThreadControl != nil:
+		return EventKindRequestThreadControl
+	}
+	return EventKindUnknown
+}
+
+/*
+MessageEvent - Message sent by the user or, when IsEcho is true, by the Page
+*/
+type MessageEvent struct {
+	Mid         string       `json:"mid"`
+	Seq         int          `json:"seq,omitempty"`
+	Text        string       `json:"text,omitempty"`
+	IsEcho      bool         `json:"is_echo,omitempty"`
+	AppID       int64        `json:"app_id,omitempty"`
+	Metadata    string       `json:"metadata,omitempty"`
+	QuickReply  *QuickReply  `json:"quick_reply,omitempty"`
+	Attachments []Attachment `json:"attachments,omitempty"`
+}
+
+/*
+QuickReply - Quick reply tapped by the user.
+For user_phone_number and user_email quick replies Payload is the phone number or email of the user.
+*/
+type QuickReply struct {
+	Payload string `json:"payload"`
+}
+
+var emailPattern = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+
+var phoneNumberPattern = regexp.MustCompile(`^\+?[0-9][0-9 ().-]{5,}[0-9]$`)
+
+/*
+Email returns the email sent by a user_email quick reply
+*/
+func (q *QuickReply) Email() (email string, ok bool) {
+	payload := strings.TrimSpace(q.Payload)
+	if !emailPattern.MatchString(payload) {
+		return "", false
+	}
+	return payload, true
+}
+
+/*
+PhoneNumber returns the phone number sent by a user_phone_number quick reply
+*/
+func (q *QuickReply) PhoneNumber() (phoneNumber string, ok bool) {
+	payload := strings.TrimSpace(q.Payload)
+	if !phoneNumberPattern.MatchString(payload) {
+		return "", false
+	}
+	return payload, true
+}
+
+/*
+Attachment - Attachment of a message (image, audio, video, file, location, fallback...)
+*/
+type Attachment struct {
+	Type    string            `json:"type"`
+	Title   string            `json:"title,omitempty"`
+	URL     string            `json:"url,omitempty"`
+	Payload AttachmentPayload `json:"payload"`
+}
+
+/*
+AttachmentPayload - Content of an Attachment
+*/
+type AttachmentPayload struct {
+	URL         string       `json:"url,omitempty"`
+	Coordinates *Coordinates `json:"coordinates,omitempty"`
+}
+
+/*
+Coordinates - Location shared by the user
+*/
+type Coordinates struct {
+	Latitude  float32 `json:"lat"`
+	Longitude float32 `json:"long"`
+}
+
+/*
+PostbackEvent - Tap on a postback button, Get Started button or persistent menu item
+*/
+type PostbackEvent struct {
+	Mid      string         `json:"mid,omitempty"`
+	Title    string         `json:"title,omitempty"`
+	Payload  string         `json:"payload"`
+	Referral *ReferralEvent `json:"referral,omitempty"`
+}
+
+/*
+DeliveryEvent - Messages sent by the Page that were delivered. Mids are the MessageID returned by the Send API.
+*/
+type DeliveryEvent struct {
+	Mids      []string `json:"mids,omitempty"`
+	Watermark int64    `json:"watermark"`
+	Seq       int      `json:"seq,omitempty"`
+}
+
+/*
+ReadEvent - Every message sent by the Page before Watermark was read
+*/
+type ReadEvent struct {
+	Watermark int64 `json:"watermark"`
+	Seq       int   `json:"seq,omitempty"`
+}
+
+/*
+ReferralEvent - Origin of the user: m.me link, ad, chat plugin...
+*/
+type ReferralEvent struct {
+	Value      string `json:"ref,omitempty"`
+	Source     string `json:"source,omitempty"`
+	Type       string `json:"type,omitempty"`
+	AdID       string `json:"ad_id,omitempty"`
+	RefererURI string `json:"referer_uri,omitempty"`
+}
+
+/*
+PassThreadControlEvent - The control of the conversation was passed to the app.
+App IDs are json.Number because Facebook sends them either as numbers or as strings.
+*/
+type PassThreadControlEvent struct {
+	NewOwnerAppID      json.Number `json:"new_owner_app_id"`
+	PreviousOwnerAppID json.Number `json:"previous_owner_app_id,omitempty"`
+	Metadata           string      `json:"metadata,omitempty"`
+}
+
+/*
+TakeThreadControlEvent - The Primary Receiver took the control of the conversation from the app
+*/
+type TakeThreadControlEvent struct {
+	PreviousOwnerAppID json.Number `json:"previous_owner_app_id"`
+	NewOwnerAppID      json.Number `json:"new_owner_app_id,omitempty"`
+	Metadata           string      `json:"metadata,omitempty"`
+}
+
+/*
+RequestThreadControlEvent - A Secondary Receiver asked the app, as Primary Receiver, for the control of the conversation
+*/
+type RequestThreadControlEvent struct {
+	RequestedOwnerAppID json.Number `json:"requested_owner_app_id"`
+	Metadata            string      `json:"metadata,omitempty"`
+}
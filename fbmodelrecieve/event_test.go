@@ -0,0 +1,195 @@
+package fbmodelrecieve
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const testWebhookPayload = `{
+	"object": "page",
+	"entry": [{
+		"id": "1070203333093348",
+		"time": 1478076699002,
+		"messaging": [
+			{"sender": {"id": "1160103300748406"}, "recipient": {"id": "1070203333093348"}, "timestamp": 1478076694000,
+				"message": {"mid": "mid.1", "text": "Hi"}},
+			{"sender": {"id": "1070203333093348"}, "recipient": {"id": "1160103300748406"}, "timestamp": 1478076694758,
+				"message": {"is_echo": true, "app_id": 1793577977581304, "mid": "mid.2", "seq": 197, "text": "Hello"}},
+			{"sender": {"id": "1160103300748406"}, "recipient": {"id": "1070203333093348"}, "timestamp": 1478076695000,
+				"message": {"mid": "mid.3", "text": "Red", "quick_reply": {"payload": "COLOR_RED"}}},
+			{"sender": {"id": "1160103300748406"}, "recipient": {"id": "1070203333093348"}, "timestamp": 1478076695000,
+				"message": {"mid": "mid.4", "attachments": [{"type": "location", "payload": {"coordinates": {"lat": -23.5, "long": -46.6}}}]}},
+			{"sender": {"id": "1160103300748406"}, "recipient": {"id": "1070203333093348"}, "timestamp": 1478076696000,
+				"postback": {"title": "Get Started", "payload": "GET_STARTED", "referral": {"ref": "ad", "source": "ADS", "type": "OPEN_THREAD"}}},
+			{"sender": {"id": "1160103300748406"}, "recipient": {"id": "1070203333093348"}, "timestamp": 0,
+				"delivery": {"mids": ["mid.2"], "watermark": 1478076694758, "seq": 198}},
+			{"sender": {"id": "1160103300748406"}, "recipient": {"id": "1070203333093348"}, "timestamp": 1478076695056,
+				"read": {"watermark": 1478076694758, "seq": 199}},
+			{"sender": {"id": "1160103300748406"}, "recipient": {"id": "1070203333093348"}, "timestamp": 1478076697000,
+				"referral": {"ref": "summer", "source": "SHORTLINK", "type": "OPEN_THREAD"}},
+			{"sender": {"id": "1160103300748406"}, "recipient": {"id": "1070203333093348"}, "timestamp": 1478076698000,
+				"optin": {"ref": "plugin"}}
+		]
+	}]
+}`
+
+func TestMessagingEventKind(t *testing.T) {
+	payload := new(FacebookMessageRecieved)
+	if err := json.Unmarshal([]byte(testWebhookPayload), payload); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if len(payload.Entry) != 1 {
+		t.Fatalf("entries = %d, want 1", len(payload.Entry))
+	}
+	events := payload.Entry[0].Messaging
+	want := []EventKind{
+		EventKindMessage,
+		EventKindEcho,
+		EventKindMessage,
+		EventKindMessage,
+		EventKindPostback,
+		EventKindDelivery,
+		EventKindRead,
+		EventKindReferral,
+		EventKindUnknown,
+	}
+	if len(events) != len(want) {
+		t.Fatalf("events = %d, want %d", len(events), len(want))
+	}
+	for i, kind := range want {
+		if got := events[i].Kind(); got != kind {
+			t.Errorf("events[%d].Kind() = %s, want %s", i, got, kind)
+		}
+	}
+
+	if echo := events[1].Message; echo.AppID != 1793577977581304 || echo.Seq != 197 {
+		t.Errorf("echo = %+v, want app_id and seq decoded", echo)
+	}
+	if qr := events[2].Message.QuickReply; qr == nil || qr.Payload != "COLOR_RED" {
+		t.Errorf("quick reply = %+v, want COLOR_RED", qr)
+	}
+	if coordinates := events[3].Message.Attachments[0].Payload.Coordinates; coordinates == nil || coordinates.Latitude != -23.5 {
+		t.Errorf("coordinates = %+v, want the location", coordinates)
+	}
+	if postback := events[4].Postback; postback.Payload != "GET_STARTED" || postback.Referral == nil || postback.Referral.Value != "ad" {
+		t.Errorf("postback = %+v, want GET_STARTED with its referral", postback)
+	}
+	if delivery := events[5].Delivery; len(delivery.Mids) != 1 || delivery.Watermark != 1478076694758 {
+		t.Errorf("delivery = %+v, want mids and watermark", delivery)
+	}
+	if events[6].Sender.ID != "1160103300748406" || events[6].Timestamp != 1478076695056 {
+		t.Errorf("read sender and timestamp = %s %d", events[6].Sender.ID, events[6].Timestamp)
+	}
+}
+
+func TestMessagingEventKindEmpty(t *testing.T) {
+	if got := new(MessagingEvent).Kind(); got != EventKindUnknown {
+		t.Errorf("Kind() of an empty event = %s, want %s", got, EventKindUnknown)
+	}
+}
+
+func TestQuickReplyEmail(t *testing.T) {
+	tests := []struct {
+		payload string
+		want    string
+		ok      bool
+	}{
+		{"jane@example.com", "jane@example.com", true},
+		{" jane.doe+bot@mail.example.com.br ", "jane.doe+bot@mail.example.com.br", true},
+		{"jane@example", "", false},
+		{"jane@@example.com", "", false},
+		{"jane doe@example.com", "", false},
+		{"SIZE_M", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := (&QuickReply{Payload: tt.payload}).Email()
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Email() of %q = %q, %v, want %q, %v", tt.payload, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestQuickReplyPhoneNumber(t *testing.T) {
+	tests := []struct {
+		payload string
+		want    string
+		ok      bool
+	}{
+		{"+5511999998888", "+5511999998888", true},
+		{"+1 (650) 555-0100", "+1 (650) 555-0100", true},
+		{" 650.555.0100 ", "650.555.0100", true},
+		{"12345", "", false},
+		{"+55 11 9999-888a", "", false},
+		{"jane@example.com", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := (&QuickReply{Payload: tt.payload}).PhoneNumber()
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("PhoneNumber() of %q = %q, %v, want %q, %v", tt.payload, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestEntryStandbyAndThreadControl(t *testing.T) {
+	//Facebook sends the app IDs of thread control events as numbers on some versions and as strings on others
+	const payload = `{
+		"object": "page",
+		"entry": [{
+			"id": "1070203333093348",
+			"time": 1478076699002,
+			"messaging": [
+				{"sender": {"id": "1160103300748406"}, "recipient": {"id": "1070203333093348"}, "timestamp": 1478076694000,
+					"pass_thread_control": {"new_owner_app_id": 123456789, "previous_owner_app_id": "987654321", "metadata": "done"}},
+				{"sender": {"id": "1160103300748406"}, "recipient": {"id": "1070203333093348"}, "timestamp": 1478076695000,
+					"take_thread_control": {"previous_owner_app_id": "123456789", "metadata": "human"}},
+				{"sender": {"id": "1160103300748406"}, "recipient": {"id": "1070203333093348"}, "timestamp": 1478076696000,
+					"request_thread_control": {"requested_owner_app_id": 123456789, "metadata": "help"}}
+			],
+			"standby": [
+				{"sender": {"id": "1160103300748406"}, "recipient": {"id": "1070203333093348"}, "timestamp": 1478076697000,
+					"message": {"mid": "mid.5", "text": "Is anyone there?"}},
+				{"sender": {"id": "1070203333093348"}, "recipient": {"id": "1160103300748406"}, "timestamp": 1478076698000,
+					"message": {"is_echo": true, "app_id": 123456789, "mid": "mid.6", "text": "Yes"}}
+			]
+		}]
+	}`
+	received := new(FacebookMessageRecieved)
+	if err := json.Unmarshal([]byte(payload), received); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	entry := received.Entry[0]
+
+	wantMessaging := []EventKind{EventKindPassThreadControl, EventKindTakeThreadControl, EventKindRequestThreadControl}
+	if len(entry.Messaging) != len(wantMessaging) {
+		t.Fatalf("messaging events = %d, want %d", len(entry.Messaging), len(wantMessaging))
+	}
+	for i, kind := range wantMessaging {
+		if got := entry.Messaging[i].Kind(); got != kind {
+			t.Errorf("messaging[%d].Kind() = %s, want %s", i, got, kind)
+		}
+	}
+	if pass := entry.Messaging[0].PassThreadControl; pass.NewOwnerAppID != "123456789" || pass.PreviousOwnerAppID != "987654321" {
+		t.Errorf("pass_thread_control = %+v, want the app IDs as text", pass)
+	}
+	if take := entry.Messaging[1].TakeThreadControl; take.PreviousOwnerAppID != "123456789" || take.NewOwnerAppID != "" {
+		t.Errorf("take_thread_control = %+v, want only the previous owner", take)
+	}
+	if request := entry.Messaging[2].RequestThreadControl; request.RequestedOwnerAppID != "123456789" || request.Metadata != "help" {
+		t.Errorf("request_thread_control = %+v, want the requested owner and metadata", request)
+	}
+
+	wantStandby := []EventKind{EventKindMessage, EventKindEcho}
+	if len(entry.Standby) != len(wantStandby) {
+		t.Fatalf("standby events = %d, want %d", len(entry.Standby), len(wantStandby))
+	}
+	for i, kind := range wantStandby {
+		if got := entry.Standby[i].Kind(); got != kind {
+			t.Errorf("standby[%d].Kind() = %s, want %s", i, got, kind)
+		}
+	}
+	if entry.Standby[0].Message.Text != "Is anyone there?" {
+		t.Errorf("standby[0] text = %q", entry.Standby[0].Message.Text)
+	}
+}
@@ -4,64 +4,19 @@ package fbmodelrecieve
 FacebookMessageRecieved - Facebook Message Received Object
 */
 type FacebookMessageRecieved struct {
-	Object string `json:"object"`
-	Entry  []struct {
-		ID        string `json:"id"`
-		Time      int64  `json:"time"`
-		Messaging []struct {
-			Sender struct {
-				ID string `json:"id"`
-			} `json:"sender"`
-			Recipient struct {
-				ID string `json:"id"`
-			} `json:"recipient"`
-			Referral struct {
-				Value  string `json:"ref"`
-				Source string `json:"source"`
-				Type   string `json:"type"`
-			} `json:"referral"`
-			//Testar colocar todos os campos e ver se o Macaron faz o bind e deixa nulo
-			//quando nao tiver esse dado
-			Timestamp int64 `json:"timestamp"`
-			Read      struct {
-				Watermark int `json:"watermark"`
-				Seq       int `json:"seq"`
-			} `json:"read"`
-			Delivery struct {
-				Mids []string `json:"mids"`
-			} `json:"delivery"`
-			Message struct {
-				Mid        string `json:"mid"`
-				Seq        int    `json:"seq"`
-				Text       string `json:"text"`
-				IsEcho     bool   `json:"is_echo"`
-				AppID      int    `json:"app_id"`
-				QuickReply struct {
-					Payload string `json:"payload"`
-				} `json:"quick_reply"`
-				Attachments []struct {
-					Type    string `json:"type"`
-					Title   string `json:"title"`
-					URL     string `json:"url"`
-					Payload struct {
-						URL         string `json:"url"`
-						Coordinates struct {
-							Latitude  float32 `json:"lat"`
-							Longitude float32 `json:"long"`
-						} `json:"coordinates"`
-					} `json:"payload"`
-				} `json:"attachments"`
-			} `json:"message"`
-			Postback struct {
-				Payload  string `json:"payload"`
-				Referral struct {
-					Value  string `json:"ref"`
-					Source string `json:"source"`
-					Type   string `json:"type"`
-				} `json:"referral"`
-			} `json:"postback"`
-		} `json:"messaging"`
-	} `json:"entry"`
+	Object string  `json:"object"`
+	Entry  []Entry `json:"entry"`
+}
+
+/*
+Entry - Batch of events of a Page sent in the same Webhook call.
+Standby has the events of conversations controlled by another app (Handover Protocol).
+*/
+type Entry struct {
+	ID        string           `json:"id"`
+	Time      int64            `json:"time"`
+	Messaging []MessagingEvent `json:"messaging,omitempty"`
+	Standby   []MessagingEvent `json:"standby,omitempty"`
 }
 
 /*
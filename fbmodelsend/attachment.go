@@ -1,11 +1,39 @@
 package fbmodelsend
 
+import "io"
+
+//AttachmentTypeImage is the type of image attachments
+const AttachmentTypeImage = "image"
+
+//AttachmentTypeAudio is the type of audio attachments
+const AttachmentTypeAudio = "audio"
+
+//AttachmentTypeVideo is the type of video attachments
+const AttachmentTypeVideo = "video"
+
+//AttachmentTypeFile is the type of generic file attachments
+const AttachmentTypeFile = "file"
+
+//AttachmentTypeTemplate is the type of structured template attachments
+const AttachmentTypeTemplate = "template"
+
 /*
 Attachment - Represents a Facebook Message's Attachment
 */
 type Attachment struct {
 	AttachmentType string         `json:"type,omitempty"`
 	Payload        MessagePayload `json:"payload,omitempty"`
+	//File, when set, is uploaded along with the message as multipart/form-data instead of being fetched from Payload.URL
+	File *AttachmentFile `json:"-"`
+}
+
+/*
+AttachmentFile - Represents a file sent within the message request (filedata)
+*/
+type AttachmentFile struct {
+	Name     string
+	MIMEType string
+	Content  io.Reader
 }
 
 /*
@@ -15,3 +43,19 @@ type SharedAttachment struct {
 	AttachmentType string        `json:"type,omitempty"`
 	Payload        SharedPayload `json:"payload,omitempty"`
 }
+
+/*
+TemplateAttachment - Represents a template attachment whose payload has its own structure, e.g. ReceiptPayload
+*/
+type TemplateAttachment struct {
+	AttachmentType string      `json:"type"`
+	Payload        interface{} `json:"payload"`
+}
+
+/*
+AttachmentUpload - Represents an asset uploaded to the Attachment Upload API to be reused by its attachment_id
+More details at https://developers.facebook.com/docs/messenger-platform/reference/attachment-upload-api
+*/
+type AttachmentUpload struct {
+	Message Message `json:"message"`
+}
@@ -0,0 +1,251 @@
+package fbmodelsend
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+//Messenger Platform limits checked by LetterBuilder
+const (
+	maxTextLength               = 2000
+	maxButtonTemplateTextLength = 640
+	maxQuickReplies             = 13
+	maxQuickReplyTitleLength    = 20
+	maxQuickReplyPayloadLength  = 1000
+	maxButtons                  = 3
+	maxGenericElements          = 10
+	maxElementTitleLength       = 80
+	maxElementSubtitleLength    = 80
+)
+
+/*
+ValidationError - Lists every Messenger Platform rule a message or a Messenger Profile breaks
+*/
+type ValidationError struct {
+	Violations []string
+}
+
+//Error implements error interface
+func (e *ValidationError) Error() string {
+	return "[fbmodelsend] Messenger Platform limits broken: " + strings.Join(e.Violations, "; ")
+}
+
+/*
+LetterBuilder builds a Letter step by step and checks it against the Messenger Platform limits on Build:
+
+	letter, err := fbmodelsend.NewLetterBuilder(recipientID).
+		Text("Which size?").
+		QuickReply(&fbmodelsend.QuickReply{ContentType: "text", Title: "Small", Payload: "SIZE_S"}).
+		Build()
+*/
+type LetterBuilder struct {
+	letter Letter
+}
+
+/*
+NewLetterBuilder starts a Letter to recipient with RESPONSE messaging type
+*/
+func NewLetterBuilder(recipient string) *LetterBuilder {
+	b := new(LetterBuilder)
+	b.letter.Recipient.ID = recipient
+	b.letter.MessageType = MessagingTypeResponse
+	return b
+}
+
+//Text sets the text of the message
+func (b *LetterBuilder) Text(text string) *LetterBuilder {
+	b.letter.Message.Text = text
+	return b
+}
+
+//Attachment sets the attachment of the message
+func (b *LetterBuilder) Attachment(attachment *Attachment) *LetterBuilder {
+	b.letter.Message.Attachment = attachment
+	return b
+}
+
+//AttachmentURL attaches the asset (image, audio, video or file) at url
+func (b *LetterBuilder) AttachmentURL(attachmentType string, url string) *LetterBuilder {
+	attch := &Attachment{AttachmentType: attachmentType}
+	attch.Payload.URL = url
+	return b.Attachment(attch)
+}
+
+//AttachmentID attaches an asset previously uploaded to the Attachment Upload API
+func (b *LetterBuilder) AttachmentID(attachmentType string, attachmentID string) *LetterBuilder {
+	attch := &Attachment{AttachmentType: attachmentType}
+	attch.Payload.AttachmentID = attachmentID
+	return b.Attachment(attch)
+}
+
+//GenericTemplate attaches a Generic Template with elements
+func (b *LetterBuilder) GenericTemplate(elements ...*TemplateElement) *LetterBuilder {
+	attch := &Attachment{AttachmentType: AttachmentTypeTemplate}
+	attch.Payload.TemplateType = TemplateTypeGeneric
+	attch.Payload.Elements = elements
+	return b.Attachment(attch)
+}
+
+//ButtonTemplate attaches a Button Template with text and buttons
+func (b *LetterBuilder) ButtonTemplate(text string, buttons ...*Button) *LetterBuilder {
+	attch := &Attachment{AttachmentType: AttachmentTypeTemplate}
+	attch.Payload.TemplateType = TemplateTypeButton
+	attch.Payload.Text = text
+	attch.Payload.Buttons = buttons
+	return b.Attachment(attch)
+}
+
+//MediaTemplate attaches a Media Template with element
+func (b *LetterBuilder) MediaTemplate(element *TemplateElement) *LetterBuilder {
+	attch := &Attachment{AttachmentType: AttachmentTypeTemplate}
+	attch.Payload.TemplateType = TemplateTypeMedia
+	attch.Payload.Elements = []*TemplateElement{element}
+	return b.Attachment(attch)
+}
+
+//QuickReply adds quick replies to the message
+func (b *LetterBuilder) QuickReply(quickReplies ...*QuickReply) *LetterBuilder {
+	b.letter.Message.QuickReplies = append(b.letter.Message.QuickReplies, quickReplies...)
+	return b
+}
+
+//MessagingType sets the messaging type: RESPONSE, UPDATE or MESSAGE_TAG
+func (b *LetterBuilder) MessagingType(messagingType string) *LetterBuilder {
+	b.letter.MessageType = messagingType
+	return b
+}
+
+//Tag sets the message tag and the MESSAGE_TAG messaging type
+func (b *LetterBuilder) Tag(tag string) *LetterBuilder {
+	b.letter.Tag = tag
+	b.letter.MessageType = MessagingTypeMessageTag
+	return b
+}
+
+//NotificationType sets how the recipient is notified: REGULAR, SILENT_PUSH or NO_PUSH
+func (b *LetterBuilder) NotificationType(notificationType string) *LetterBuilder {
+	b.letter.NotificationType = notificationType
+	return b
+}
+
+/*
+Build returns the Letter, or a *ValidationError listing every limit it breaks
+*/
+func (b *LetterBuilder) Build() (*Letter, error) {
+	v := new(violations)
+	letter := b.letter
+	msg := &letter.Message
+
+	v.check(letter.Recipient.ID != "", "recipient is required")
+	switch letter.MessageType {
+	case MessagingTypeResponse, MessagingTypeUpdate:
+	case MessagingTypeMessageTag:
+		v.check(letter.Tag != "", "MESSAGE_TAG messaging type requires a tag")
+	default:
+		v.add("invalid messaging type [%s]", letter.MessageType)
+	}
+	switch letter.NotificationType {
+	case "", NotificationTypeRegular, NotificationTypeSilentPush, NotificationTypeNoPush:
+	default:
+		v.add("invalid notification type [%s]", letter.NotificationType)
+	}
+
+	v.check(msg.Text != "" || msg.Attachment != nil, "message requires text or attachment")
+	v.check(msg.Text == "" || msg.Attachment == nil, "message can't have both text and attachment")
+	v.maxLength(msg.Text, maxTextLength, "text")
+
+	v.check(len(msg.QuickReplies) <= maxQuickReplies, fmt.Sprintf("up to %d quick replies are allowed, got %d", maxQuickReplies, len(msg.QuickReplies)))
+	for i, qr := range msg.QuickReplies {
+		if qr == nil {
+			v.add("quick_replies[%d] is nil", i)
+			continue
+		}
+		if qr.ContentType == "text" {
+			v.check(qr.Title != "" || qr.ImageURL != "", fmt.Sprintf("quick_replies[%d] requires title or image_url", i))
+			v.check(qr.Payload != "", fmt.Sprintf("quick_replies[%d] requires payload", i))
+		}
+		v.maxLength(qr.Title, maxQuickReplyTitleLength, fmt.Sprintf("quick_replies[%d].title", i))
+		v.maxLength(qr.Payload, maxQuickReplyPayloadLength, fmt.Sprintf("quick_replies[%d].payload", i))
+	}
+
+	if msg.Attachment != nil && msg.Attachment.AttachmentType == AttachmentTypeTemplate {
+		v.template(&msg.Attachment.Payload)
+	}
+
+	if len(v.list) > 0 {
+		return nil, &ValidationError{Violations: v.list}
+	}
+	return &letter, nil
+}
+
+/*
+violations collects the broken rules of a message
+*/
+type violations struct {
+	list []string
+}
+
+func (v *violations) add(format string, args ...interface{}) {
+	v.list = append(v.list, fmt.Sprintf(format, args...))
+}
+
+func (v *violations) check(ok bool, violation string) {
+	if !ok {
+		v.list = append(v.list, violation)
+	}
+}
+
+func (v *violations) maxLength(value string, limit int, field string) {
+	if n := utf8.RuneCountInString(value); n > limit {
+		v.add("%s must have up to %d characters, got %d", field, limit, n)
+	}
+}
+
+func (v *violations) buttons(buttons []*Button, field string) {
+	v.check(len(buttons) <= maxButtons, fmt.Sprintf("%s: up to %d buttons are allowed, got %d", field, maxButtons, len(buttons)))
+	for i, btn := range buttons {
+		if btn == nil {
+			v.add("%s[%d] is nil", field, i)
+			continue
+		}
+		if err := btn.Validate(); err != nil {
+			v.add("%s[%d]: %s", field, i, err.Error())
+		}
+	}
+}
+
+func (v *violations) template(payload *MessagePayload) {
+	switch payload.TemplateType {
+	case TemplateTypeGeneric:
+		v.check(len(payload.Elements) > 0, "generic template requires at least one element")
+		v.check(len(payload.Elements) <= maxGenericElements, fmt.Sprintf("up to %d generic template elements are allowed, got %d", maxGenericElements, len(payload.Elements)))
+		for i, elem := range payload.Elements {
+			if elem == nil {
+				v.add("elements[%d] is nil", i)
+				continue
+			}
+			v.check(elem.Title != "", fmt.Sprintf("elements[%d] requires title", i))
+			v.maxLength(elem.Title, maxElementTitleLength, fmt.Sprintf("elements[%d].title", i))
+			v.maxLength(elem.Subtitle, maxElementSubtitleLength, fmt.Sprintf("elements[%d].subtitle", i))
+			v.buttons(elem.Buttons, fmt.Sprintf("elements[%d].buttons", i))
+		}
+	case TemplateTypeButton:
+		v.check(payload.Text != "", "button template requires text")
+		v.maxLength(payload.Text, maxButtonTemplateTextLength, "button template text")
+		v.check(len(payload.Buttons) > 0, "button template requires at least one button")
+		v.buttons(payload.Buttons, "buttons")
+	case TemplateTypeMedia:
+		if len(payload.Elements) != 1 || payload.Elements[0] == nil {
+			v.add("media template requires exactly one element")
+			return
+		}
+		elem := payload.Elements[0]
+		v.check(elem.MediaType == MediaTypeImage || elem.MediaType == MediaTypeVideo, "media template element requires media_type image or video")
+		v.check((elem.AttachmentID == "") != (elem.URL == ""), "media template element requires either attachment_id or url")
+		v.check(len(elem.Buttons) <= 1, "media template supports up to one button")
+		v.buttons(elem.Buttons, "elements[0].buttons")
+	default:
+		v.add("invalid template type [%s]", payload.TemplateType)
+	}
+}
@@ -0,0 +1,165 @@
+package fbmodelsend
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+//buildViolations builds b and returns the violations of its *ValidationError
+func buildViolations(t *testing.T, b *LetterBuilder) []string {
+	t.Helper()
+	_, err := b.Build()
+	if err == nil {
+		return nil
+	}
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Build() error = %v, want *ValidationError", err)
+	}
+	return validationErr.Violations
+}
+
+func textQuickReplies(n int) []*QuickReply {
+	qrs := make([]*QuickReply, n)
+	for i := range qrs {
+		qrs[i] = NewTextQuickReply(fmt.Sprintf("Option %d", i), fmt.Sprintf("OPTION_%d", i))
+	}
+	return qrs
+}
+
+func postbackButtons(n int) []*Button {
+	buttons := make([]*Button, n)
+	for i := range buttons {
+		buttons[i] = NewPostbackButton(fmt.Sprintf("Button %d", i), fmt.Sprintf("BUTTON_%d", i))
+	}
+	return buttons
+}
+
+func genericElements(n int) []*TemplateElement {
+	elements := make([]*TemplateElement, n)
+	for i := range elements {
+		elements[i] = &TemplateElement{Title: fmt.Sprintf("Element %d", i)}
+	}
+	return elements
+}
+
+func TestLetterBuilderValid(t *testing.T) {
+	letter, err := NewLetterBuilder("42").
+		Text(strings.Repeat("a", maxTextLength)).
+		QuickReply(textQuickReplies(maxQuickReplies)...).
+		Tag("CONFIRMED_EVENT_UPDATE").
+		NotificationType(NotificationTypeSilentPush).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	data, err := json.Marshal(letter)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var fields map[string]interface{}
+	json.Unmarshal(data, &fields)
+	if fields["messaging_type"] != MessagingTypeMessageTag || fields["tag"] != "CONFIRMED_EVENT_UPDATE" {
+		t.Errorf("JSON = %s, want messaging_type MESSAGE_TAG with its tag", data)
+	}
+	if _, ok := fields["message_type"]; ok {
+		t.Errorf("JSON = %s, messaging type sent as message_type", data)
+	}
+}
+
+func TestLetterBuilderLimits(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *LetterBuilder
+		want    string
+	}{
+		{"text", NewLetterBuilder("42").Text(strings.Repeat("a", maxTextLength+1)),
+			"text must have up to 2000 characters"},
+		{"quick replies", NewLetterBuilder("42").Text("Pick one").QuickReply(textQuickReplies(maxQuickReplies + 1)...),
+			"up to 13 quick replies are allowed, got 14"},
+		{"quick reply title", NewLetterBuilder("42").Text("Pick one").QuickReply(NewTextQuickReply(strings.Repeat("a", maxQuickReplyTitleLength+1), "P")),
+			"quick_replies[0].title must have up to 20 characters"},
+		{"nil quick reply", NewLetterBuilder("42").Text("Pick one").QuickReply(nil),
+			"quick_replies[0] is nil"},
+		{"button template buttons", NewLetterBuilder("42").ButtonTemplate("Pick one", postbackButtons(maxButtons+1)...),
+			"buttons: up to 3 buttons are allowed, got 4"},
+		{"button template text", NewLetterBuilder("42").ButtonTemplate(strings.Repeat("a", maxButtonTemplateTextLength+1), postbackButtons(1)...),
+			"button template text must have up to 640 characters"},
+		{"generic elements", NewLetterBuilder("42").GenericTemplate(genericElements(maxGenericElements + 1)...),
+			"up to 10 generic template elements are allowed, got 11"},
+		{"generic element title", NewLetterBuilder("42").GenericTemplate(&TemplateElement{Title: strings.Repeat("a", maxElementTitleLength+1)}),
+			"elements[0].title must have up to 80 characters"},
+		{"generic element buttons", NewLetterBuilder("42").GenericTemplate(&TemplateElement{Title: "Shirt", Buttons: postbackButtons(maxButtons + 1)}),
+			"elements[0].buttons: up to 3 buttons are allowed, got 4"},
+		{"nil generic element", NewLetterBuilder("42").GenericTemplate(nil),
+			"elements[0] is nil"},
+		{"media without element", NewLetterBuilder("42").MediaTemplate(nil),
+			"media template requires exactly one element"},
+		{"media type", NewLetterBuilder("42").MediaTemplate(&TemplateElement{MediaType: "gif", URL: "https://www.facebook.com/photo.php?fbid=1"}),
+			"media template element requires media_type image or video"},
+		{"media URL and attachment ID", NewLetterBuilder("42").MediaTemplate(&TemplateElement{MediaType: MediaTypeImage, URL: "https://www.facebook.com/photo.php?fbid=1", AttachmentID: "1"}),
+			"media template element requires either attachment_id or url"},
+		{"media without URL nor attachment ID", NewLetterBuilder("42").MediaTemplate(&TemplateElement{MediaType: MediaTypeVideo}),
+			"media template element requires either attachment_id or url"},
+		{"media buttons", NewLetterBuilder("42").MediaTemplate(&TemplateElement{MediaType: MediaTypeImage, AttachmentID: "1", Buttons: postbackButtons(2)}),
+			"media template supports up to one button"},
+		{"messaging type", NewLetterBuilder("42").Text("hi").MessagingType("PROMOTION"),
+			"invalid messaging type [PROMOTION]"},
+		{"message tag", NewLetterBuilder("42").Text("hi").MessagingType(MessagingTypeMessageTag),
+			"MESSAGE_TAG messaging type requires a tag"},
+		{"notification type", NewLetterBuilder("42").Text("hi").NotificationType("LOUD"),
+			"invalid notification type [LOUD]"},
+		{"text and attachment", NewLetterBuilder("42").Text("hi").AttachmentURL(AttachmentTypeImage, "https://example.com/a.png"),
+			"message can't have both text and attachment"},
+		{"empty message", NewLetterBuilder("42"),
+			"message requires text or attachment"},
+		{"recipient", NewLetterBuilder("").Text("hi"),
+			"recipient is required"},
+	}
+	for _, tt := range tests {
+		violations := buildViolations(t, tt.builder)
+		found := false
+		for _, violation := range violations {
+			if strings.Contains(violation, tt.want) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("%s: violations = %q, want %q", tt.name, violations, tt.want)
+		}
+	}
+}
+
+func TestLetterBuilderMediaTemplateValid(t *testing.T) {
+	_, err := NewLetterBuilder("42").
+		MediaTemplate(&TemplateElement{MediaType: MediaTypeVideo, URL: "https://www.facebook.com/video.php?v=1", Buttons: postbackButtons(1)}).
+		Build()
+	if err != nil {
+		t.Errorf("Build() error = %v", err)
+	}
+}
+
+func TestLetterBuilderReportsEveryViolation(t *testing.T) {
+	violations := buildViolations(t, NewLetterBuilder("").
+		Text(strings.Repeat("a", maxTextLength+1)).
+		QuickReply(textQuickReplies(maxQuickReplies+1)...).
+		MessagingType("PROMOTION"))
+	want := []string{
+		"recipient is required",
+		"invalid messaging type [PROMOTION]",
+		"text must have up to 2000 characters, got 2001",
+		"up to 13 quick replies are allowed, got 14",
+	}
+	if len(violations) != len(want) {
+		t.Fatalf("violations = %q, want %q", violations, want)
+	}
+	for i := range want {
+		if violations[i] != want[i] {
+			t.Errorf("violations[%d] = %q, want %q", i, violations[i], want[i])
+		}
+	}
+}
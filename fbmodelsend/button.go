@@ -1,13 +1,67 @@
 package fbmodelsend
 
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+//ButtonTypeWebURL opens an URL in the Messenger webview or in the browser
+const ButtonTypeWebURL = "web_url"
+
+//ButtonTypePostback sends a postback event with Payload to the Webhook
+const ButtonTypePostback = "postback"
+
+//ButtonTypePhoneNumber calls the phone number set in Payload
+const ButtonTypePhoneNumber = "phone_number"
+
+//ButtonTypeAccountLink starts the account linking flow at URL
+const ButtonTypeAccountLink = "account_link"
+
+//ButtonTypeAccountUnlink unlinks the user account
+const ButtonTypeAccountUnlink = "account_unlink"
+
+//ButtonTypeGamePlay launches an Instant Game
+const ButtonTypeGamePlay = "game_play"
+
+//ButtonTypeElementShare shares the message, see ButtonSharedContent
+const ButtonTypeElementShare = "element_share"
+
+//WebviewHeightCompact opens the webview in half of the screen
+const WebviewHeightCompact = "compact"
+
+//WebviewHeightTall opens the webview in 75% of the screen
+const WebviewHeightTall = "tall"
+
+//WebviewHeightFull opens the webview in the full screen
+const WebviewHeightFull = "full"
+
+//maxButtonTitleLength is the maximum number of characters of a button title
+const maxButtonTitleLength = 20
+
 /*
-Button - Button to be used as a reply option within a FB Message
+Button - Button to be used as a reply option within a FB Message.
+Use the constructors (NewPostbackButton, NewURLButton...) to get the fields each type requires.
 */
 type Button struct {
-	ButtonType string `json:"type,omitempty"`
-	Title      string `json:"title,omitempty"`
-	Payload    string `json:"payload,omitempty"`
-	URL        string `json:"url,omitempty"`
+	ButtonType          string        `json:"type,omitempty"`
+	Title               string        `json:"title,omitempty"`
+	Payload             string        `json:"payload,omitempty"`
+	URL                 string        `json:"url,omitempty"`
+	WebviewHeightRatio  string        `json:"webview_height_ratio,omitempty"`
+	MessengerExtensions bool          `json:"messenger_extensions,omitempty"`
+	FallbackURL         string        `json:"fallback_url,omitempty"`
+	WebviewShareButton  string        `json:"webview_share_button,omitempty"`
+	GameMetadata        *GameMetadata `json:"game_metadata,omitempty"`
+}
+
+/*
+GameMetadata - Player or context an Instant Game is launched for
+*/
+type GameMetadata struct {
+	PlayerID  string `json:"player_id,omitempty"`
+	ContextID string `json:"context_id,omitempty"`
 }
 
 /*
@@ -17,3 +71,116 @@ type ButtonSharedContent struct {
 	ButtonType    string        `json:"type,omitempty"`
 	ShareContents ShareContents `json:"share_contents,omitempty"`
 }
+
+/*
+NewPostbackButton creates a button that sends payload to the Webhook when tapped
+*/
+func NewPostbackButton(title string, payload string) *Button {
+	return &Button{ButtonType: ButtonTypePostback, Title: title, Payload: payload}
+}
+
+/*
+NewURLButton creates a button that opens url. Set WebviewHeightRatio, MessengerExtensions,
+FallbackURL and WebviewShareButton to open it in the Messenger webview.
+*/
+func NewURLButton(title string, url string) *Button {
+	return &Button{ButtonType: ButtonTypeWebURL, Title: title, URL: url}
+}
+
+/*
+NewWebviewButton creates a button that opens url in the Messenger webview with the given height ratio (compact, tall or full)
+and, when fallbackURL is set, the Messenger Extensions SDK enabled
+*/
+func NewWebviewButton(title string, url string, heightRatio string, fallbackURL string) *Button {
+	btn := NewURLButton(title, url)
+	btn.WebviewHeightRatio = heightRatio
+	if fallbackURL != "" {
+		btn.MessengerExtensions = true
+		btn.FallbackURL = fallbackURL
+	}
+	return btn
+}
+
+/*
+NewCallButton creates a button that calls phoneNumber, in the format +<country code><number>
+*/
+func NewCallButton(title string, phoneNumber string) *Button {
+	return &Button{ButtonType: ButtonTypePhoneNumber, Title: title, Payload: phoneNumber}
+}
+
+/*
+NewLoginButton creates a button that starts the account linking flow at url
+*/
+func NewLoginButton(url string) *Button {
+	return &Button{ButtonType: ButtonTypeAccountLink, URL: url}
+}
+
+/*
+NewLogoutButton creates a button that unlinks the user account
+*/
+func NewLogoutButton() *Button {
+	return &Button{ButtonType: ButtonTypeAccountUnlink}
+}
+
+/*
+NewGamePlayButton creates a button that launches an Instant Game. metadata is optional.
+*/
+func NewGamePlayButton(title string, payload string, metadata *GameMetadata) *Button {
+	return &Button{ButtonType: ButtonTypeGamePlay, Title: title, Payload: payload, GameMetadata: metadata}
+}
+
+/*
+IsValidButtonType tells whether buttonType is a button type supported by Messenger
+*/
+func IsValidButtonType(buttonType string) bool {
+	switch buttonType {
+	case ButtonTypeWebURL, ButtonTypePostback, ButtonTypePhoneNumber, ButtonTypeAccountLink,
+		ButtonTypeAccountUnlink, ButtonTypeGamePlay, ButtonTypeElementShare:
+		return true
+	}
+	return false
+}
+
+/*
+Validate checks the type of the button and the fields it requires
+*/
+func (b *Button) Validate() error {
+	if !IsValidButtonType(b.ButtonType) {
+		return fmt.Errorf("[Button] Invalid button type [%s]", b.ButtonType)
+	}
+
+	switch b.ButtonType {
+	case ButtonTypeWebURL, ButtonTypePostback, ButtonTypePhoneNumber, ButtonTypeGamePlay:
+		if strings.TrimSpace(b.Title) == "" {
+			return fmt.Errorf("[Button] Button of type [%s] requires a title", b.ButtonType)
+		}
+	}
+	if utf8.RuneCountInString(b.Title) > maxButtonTitleLength {
+		return fmt.Errorf("[Button] Button title [%s] is longer than %d characters", b.Title, maxButtonTitleLength)
+	}
+
+	switch b.ButtonType {
+	case ButtonTypeWebURL, ButtonTypeAccountLink:
+		if b.URL == "" {
+			return fmt.Errorf("[Button] Button of type [%s] requires an URL", b.ButtonType)
+		}
+	case ButtonTypePostback:
+		if b.Payload == "" {
+			return errors.New("[Button] Postback button requires a payload")
+		}
+	case ButtonTypePhoneNumber:
+		if !strings.HasPrefix(b.Payload, "+") {
+			return errors.New("[Button] Call button requires a phone number starting with + and the country code")
+		}
+	}
+
+	switch b.WebviewHeightRatio {
+	case "", WebviewHeightCompact, WebviewHeightTall, WebviewHeightFull:
+	default:
+		return fmt.Errorf("[Button] Invalid webview height ratio [%s]", b.WebviewHeightRatio)
+	}
+	if b.FallbackURL != "" && !b.MessengerExtensions {
+		return errors.New("[Button] Fallback URL is only used with Messenger Extensions")
+	}
+	return nil
+}
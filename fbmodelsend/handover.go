@@ -0,0 +1,21 @@
+package fbmodelsend
+
+//PageInboxAppID is the app ID of the Page Inbox, the usual secondary receiver of the Handover Protocol
+const PageInboxAppID int64 = 263902037430900
+
+/*
+ThreadControl - Represents a Handover Protocol request to pass, take, request or release the control of a conversation
+More details at https://developers.facebook.com/docs/messenger-platform/handover-protocol
+*/
+type ThreadControl struct {
+	Recipient   Recipient `json:"recipient"`
+	TargetAppID int64     `json:"target_app_id,omitempty"`
+	Metadata    string    `json:"metadata,omitempty"`
+}
+
+/*
+ThreadOwner - App that currently controls a conversation
+*/
+type ThreadOwner struct {
+	AppID string `json:"app_id"`
+}
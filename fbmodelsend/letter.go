@@ -1,5 +1,23 @@
 package fbmodelsend
 
+//MessagingTypeResponse is in response to a received message
+const MessagingTypeResponse = "RESPONSE"
+
+//MessagingTypeUpdate is being sent proactively and is not in response to a received message
+const MessagingTypeUpdate = "UPDATE"
+
+//MessagingTypeMessageTag is non-promotional and is being sent outside the 24-hour standard messaging window with a message tag
+const MessagingTypeMessageTag = "MESSAGE_TAG"
+
+//NotificationTypeRegular notifies the recipient with sound or vibration
+const NotificationTypeRegular = "REGULAR"
+
+//NotificationTypeSilentPush notifies the recipient on screen only
+const NotificationTypeSilentPush = "SILENT_PUSH"
+
+//NotificationTypeNoPush doesn't notify the recipient
+const NotificationTypeNoPush = "NO_PUSH"
+
 /*
 Letter is a complete message to a Facebook user.
 We use this name to refer a old letter because your message to be delivered
@@ -8,17 +26,32 @@ able to find the reciever (or recipient).
 In this case our mail company is Facebook
 */
 type Letter struct {
-	MessageType string    `json:"message_type"`
-	Tag         string    `json:"tag,omitempty"`
-	Recipient   Recipient `json:"recipient"`
-	Message     Message   `json:"message"`
+	//MessageType is sent as messaging_type: RESPONSE, UPDATE or MESSAGE_TAG
+	MessageType      string    `json:"messaging_type"`
+	Tag              string    `json:"tag,omitempty"`
+	NotificationType string    `json:"notification_type,omitempty"`
+	PersonaID        string    `json:"persona_id,omitempty"`
+	Recipient        Recipient `json:"recipient"`
+	Message          Message   `json:"message"`
 }
 
 /*
 SharedInvite represents a shared button with content where the sender wants to share with a recipient an invite
 */
 type SharedInvite struct {
-	MessageType string                   `json:"message_type"`
+	MessageType string                   `json:"messaging_type"`
 	Recipient   Recipient                `json:"recipient"`
 	Message     MessageWithSharedContent `json:"message"`
 }
+
+/*
+TemplateLetter is a complete message to a Facebook user carrying one of the templates
+whose payload has its own structure, such as receipt and airline templates
+*/
+type TemplateLetter struct {
+	MessageType string          `json:"messaging_type"`
+	Tag         string          `json:"tag,omitempty"`
+	PersonaID   string          `json:"persona_id,omitempty"`
+	Recipient   Recipient       `json:"recipient"`
+	Message     TemplateMessage `json:"message"`
+}
@@ -15,3 +15,11 @@ MessageWithSharedContent - Represents a Facebook's Shared Content Message
 type MessageWithSharedContent struct {
 	Attachment *SharedAttachment `json:"attachment,omitempty"`
 }
+
+/*
+TemplateMessage - Represents a Facebook's Message with a template that has its own payload structure
+*/
+type TemplateMessage struct {
+	Attachment   *TemplateAttachment `json:"attachment"`
+	QuickReplies []*QuickReply       `json:"quick_replies,omitempty"`
+}
@@ -1,5 +1,29 @@
 package fbmodelsend
 
+//TemplateTypeGeneric is the template type of Generic Templates
+const TemplateTypeGeneric = "generic"
+
+//TemplateTypeButton is the template type of Button Templates
+const TemplateTypeButton = "button"
+
+//TemplateTypeMedia is the template type of Media Templates
+const TemplateTypeMedia = "media"
+
+//TemplateTypeReceipt is the template type of Receipt Templates
+const TemplateTypeReceipt = "receipt"
+
+//TemplateTypeAirlineBoardingPass is the template type of Airline Boarding Pass Templates
+const TemplateTypeAirlineBoardingPass = "airline_boardingpass"
+
+//TemplateTypeAirlineCheckin is the template type of Airline Check-in Templates
+const TemplateTypeAirlineCheckin = "airline_checkin"
+
+//TemplateTypeAirlineItinerary is the template type of Airline Itinerary Templates
+const TemplateTypeAirlineItinerary = "airline_itinerary"
+
+//TemplateTypeAirlineUpdate is the template type of Airline Flight Update Templates
+const TemplateTypeAirlineUpdate = "airline_update"
+
 /*
 MessagePayload - Represents a payload of Facebook Message
 */
@@ -9,6 +33,8 @@ type MessagePayload struct {
 	URL          string             `json:"url,omitempty"`
 	Text         string             `json:"text,omitempty"`
 	Buttons      []*Button          `json:"buttons,omitempty"`
+	AttachmentID string             `json:"attachment_id,omitempty"`
+	IsReusable   bool               `json:"is_reusable,omitempty"`
 }
 
 /*
@@ -0,0 +1,12 @@
+package fbmodelsend
+
+/*
+Persona - Represents a Messenger Persona, another identity (name and profile picture) the Page can use to talk with users,
+e.g. a human agent taking over the conversation from the bot.
+More details at https://developers.facebook.com/docs/messenger-platform/send-messages/personas
+*/
+type Persona struct {
+	ID                string `json:"id,omitempty"`
+	Name              string `json:"name"`
+	ProfilePictureURL string `json:"profile_picture_url"`
+}
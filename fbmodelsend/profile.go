@@ -0,0 +1,203 @@
+package fbmodelsend
+
+import (
+	"fmt"
+	"strings"
+)
+
+//ProfileFieldGetStarted is the Messenger Profile field of the Get Started button
+const ProfileFieldGetStarted = "get_started"
+
+//ProfileFieldGreeting is the Messenger Profile field of the greeting text
+const ProfileFieldGreeting = "greeting"
+
+//ProfileFieldPersistentMenu is the Messenger Profile field of the persistent menu
+const ProfileFieldPersistentMenu = "persistent_menu"
+
+//ProfileFieldIceBreakers is the Messenger Profile field of the ice breakers
+const ProfileFieldIceBreakers = "ice_breakers"
+
+//ProfileFieldWhitelistedDomains is the Messenger Profile field of the whitelisted domains
+const ProfileFieldWhitelistedDomains = "whitelisted_domains"
+
+//ProfileFields are all the Messenger Profile fields modeled by MessengerProfile
+var ProfileFields = []string{
+	ProfileFieldGetStarted,
+	ProfileFieldGreeting,
+	ProfileFieldPersistentMenu,
+	ProfileFieldIceBreakers,
+	ProfileFieldWhitelistedDomains,
+}
+
+//DefaultLocale is the locale used when there is no variant for the user locale
+const DefaultLocale = "default"
+
+//MenuItemTypeNested is a persistent menu item that opens a submenu
+const MenuItemTypeNested = "nested"
+
+//Messenger Profile limits checked by MessengerProfile.Validate
+const (
+	maxGetStartedPayloadLength = 1000
+	maxGreetingTextLength      = 160
+	maxMenuItems               = 3
+	maxNestedMenuItems         = 5
+	maxMenuDepth               = 3
+	maxMenuItemTitleLength     = 30
+	maxIceBreakers             = 4
+	maxIceBreakerQuestionLen   = 80
+	maxWhitelistedDomains      = 50
+)
+
+/*
+MessengerProfile - Represents the Messenger Profile of a Page. Only the fields set are changed when it's sent.
+More details at https://developers.facebook.com/docs/messenger-platform/reference/messenger-profile-api
+*/
+type MessengerProfile struct {
+	GetStarted         *GetStarted       `json:"get_started,omitempty"`
+	Greeting           []*Greeting       `json:"greeting,omitempty"`
+	PersistentMenu     []*PersistentMenu `json:"persistent_menu,omitempty"`
+	IceBreakers        []*IceBreaker     `json:"ice_breakers,omitempty"`
+	WhitelistedDomains []string          `json:"whitelisted_domains,omitempty"`
+}
+
+/*
+GetStarted - Get Started button shown in the welcome screen. Payload is sent back as a postback.
+*/
+type GetStarted struct {
+	Payload string `json:"payload"`
+}
+
+/*
+Greeting - Greeting text of the welcome screen for a locale. Text may use {{user_first_name}},
+{{user_last_name}} and {{user_full_name}}.
+*/
+type Greeting struct {
+	Locale string `json:"locale"`
+	Text   string `json:"text"`
+}
+
+/*
+PersistentMenu - Persistent menu for a locale
+*/
+type PersistentMenu struct {
+	Locale                string      `json:"locale"`
+	ComposerInputDisabled bool        `json:"composer_input_disabled"`
+	CallToActions         []*MenuItem `json:"call_to_actions,omitempty"`
+}
+
+/*
+MenuItem - Item of the persistent menu: a postback, an URL or, with nested type, a submenu
+*/
+type MenuItem struct {
+	Type                string      `json:"type"`
+	Title               string      `json:"title"`
+	Payload             string      `json:"payload,omitempty"`
+	URL                 string      `json:"url,omitempty"`
+	WebviewHeightRatio  string      `json:"webview_height_ratio,omitempty"`
+	MessengerExtensions bool        `json:"messenger_extensions,omitempty"`
+	FallbackURL         string      `json:"fallback_url,omitempty"`
+	WebviewShareButton  string      `json:"webview_share_button,omitempty"`
+	CallToActions       []*MenuItem `json:"call_to_actions,omitempty"`
+}
+
+/*
+IceBreaker - Frequently asked question shown to the user when the conversation starts
+*/
+type IceBreaker struct {
+	Question string `json:"question"`
+	Payload  string `json:"payload"`
+}
+
+/*
+Validate checks the Messenger Profile limits and returns a *ValidationError listing every one broken
+*/
+func (p *MessengerProfile) Validate() error {
+	v := new(violations)
+
+	if p.GetStarted != nil {
+		v.check(p.GetStarted.Payload != "", "get_started requires payload")
+		v.maxLength(p.GetStarted.Payload, maxGetStartedPayloadLength, "get_started.payload")
+	}
+
+	locales := make(map[string]bool)
+	for i, greeting := range p.Greeting {
+		if greeting == nil {
+			v.add("greeting[%d] is nil", i)
+			continue
+		}
+		v.check(greeting.Locale != "", fmt.Sprintf("greeting[%d] requires locale", i))
+		v.check(!locales[greeting.Locale], fmt.Sprintf("greeting locale [%s] is repeated", greeting.Locale))
+		locales[greeting.Locale] = true
+		v.check(greeting.Text != "", fmt.Sprintf("greeting[%d] requires text", i))
+		v.maxLength(greeting.Text, maxGreetingTextLength, fmt.Sprintf("greeting[%d].text", i))
+	}
+	v.check(len(p.Greeting) == 0 || locales[DefaultLocale], "greeting requires the default locale")
+
+	locales = make(map[string]bool)
+	for i, menu := range p.PersistentMenu {
+		if menu == nil {
+			v.add("persistent_menu[%d] is nil", i)
+			continue
+		}
+		v.check(menu.Locale != "", fmt.Sprintf("persistent_menu[%d] requires locale", i))
+		v.check(!locales[menu.Locale], fmt.Sprintf("persistent_menu locale [%s] is repeated", menu.Locale))
+		locales[menu.Locale] = true
+		v.check(len(menu.CallToActions) > 0 || menu.ComposerInputDisabled, fmt.Sprintf("persistent_menu[%d] requires call_to_actions", i))
+		v.menuItems(menu.CallToActions, fmt.Sprintf("persistent_menu[%d].call_to_actions", i), 1)
+	}
+	v.check(len(p.PersistentMenu) == 0 || locales[DefaultLocale], "persistent_menu requires the default locale")
+	v.check(len(p.PersistentMenu) == 0 || p.GetStarted != nil, "persistent_menu requires get_started")
+
+	v.check(len(p.IceBreakers) <= maxIceBreakers, fmt.Sprintf("up to %d ice_breakers are allowed, got %d", maxIceBreakers, len(p.IceBreakers)))
+	for i, iceBreaker := range p.IceBreakers {
+		if iceBreaker == nil {
+			v.add("ice_breakers[%d] is nil", i)
+			continue
+		}
+		v.check(iceBreaker.Question != "" && iceBreaker.Payload != "", fmt.Sprintf("ice_breakers[%d] requires question and payload", i))
+		v.maxLength(iceBreaker.Question, maxIceBreakerQuestionLen, fmt.Sprintf("ice_breakers[%d].question", i))
+	}
+
+	v.check(len(p.WhitelistedDomains) <= maxWhitelistedDomains, fmt.Sprintf("up to %d whitelisted_domains are allowed, got %d", maxWhitelistedDomains, len(p.WhitelistedDomains)))
+	for i, domain := range p.WhitelistedDomains {
+		v.check(strings.HasPrefix(domain, "https://"), fmt.Sprintf("whitelisted_domains[%d] [%s] must use https", i, domain))
+	}
+
+	if len(v.list) > 0 {
+		return &ValidationError{Violations: v.list}
+	}
+	return nil
+}
+
+//menuItems checks the items of a persistent menu level
+func (v *violations) menuItems(items []*MenuItem, field string, depth int) {
+	limit := maxMenuItems
+	if depth > 1 {
+		limit = maxNestedMenuItems
+	}
+	v.check(len(items) <= limit, fmt.Sprintf("%s: up to %d items are allowed, got %d", field, limit, len(items)))
+	for i, item := range items {
+		name := fmt.Sprintf("%s[%d]", field, i)
+		if item == nil {
+			v.add("%s is nil", name)
+			continue
+		}
+		v.check(item.Title != "", name+" requires title")
+		v.maxLength(item.Title, maxMenuItemTitleLength, name+".title")
+		switch item.Type {
+		case ButtonTypePostback:
+			v.check(item.Payload != "", name+" requires payload")
+		case ButtonTypeWebURL:
+			v.check(item.URL != "", name+" requires url")
+		case MenuItemTypeNested:
+			if depth >= maxMenuDepth {
+				v.add("%s: menus can be nested up to %d levels", name, maxMenuDepth)
+				continue
+			}
+			v.check(len(item.CallToActions) > 0, name+" requires call_to_actions")
+			v.menuItems(item.CallToActions, name+".call_to_actions", depth+1)
+		default:
+			v.add("%s has invalid type [%s]", name, item.Type)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package fbmodelsend
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func postbackMenuItems(n int) []*MenuItem {
+	items := make([]*MenuItem, n)
+	for i := range items {
+		items[i] = &MenuItem{Type: ButtonTypePostback, Title: fmt.Sprintf("Item %d", i), Payload: fmt.Sprintf("ITEM_%d", i)}
+	}
+	return items
+}
+
+//nestedMenu returns a menu item nested depth levels
+func nestedMenu(depth int) *MenuItem {
+	item := &MenuItem{Type: MenuItemTypeNested, Title: "More", CallToActions: postbackMenuItems(1)}
+	if depth > 1 {
+		item.CallToActions = []*MenuItem{nestedMenu(depth - 1)}
+	}
+	return item
+}
+
+func validProfile() *MessengerProfile {
+	return &MessengerProfile{
+		GetStarted: &GetStarted{Payload: "GET_STARTED"},
+		Greeting:   []*Greeting{{Locale: DefaultLocale, Text: "Hello {{user_first_name}}!"}},
+		PersistentMenu: []*PersistentMenu{{
+			Locale:        DefaultLocale,
+			CallToActions: append(postbackMenuItems(2), nestedMenu(2)),
+		}},
+		IceBreakers:        []*IceBreaker{{Question: "Where are you?", Payload: "WHERE"}},
+		WhitelistedDomains: []string{"https://example.com"},
+	}
+}
+
+func TestMessengerProfileValidate(t *testing.T) {
+	if err := validProfile().Validate(); err != nil {
+		t.Fatalf("Validate() of a valid profile error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		change func(p *MessengerProfile)
+		want   string
+	}{
+		{"menu without get started", func(p *MessengerProfile) { p.GetStarted = nil },
+			"persistent_menu requires get_started"},
+		{"get started payload", func(p *MessengerProfile) { p.GetStarted.Payload = "" },
+			"get_started requires payload"},
+		{"greeting without default locale", func(p *MessengerProfile) { p.Greeting[0].Locale = "pt_BR" },
+			"greeting requires the default locale"},
+		{"duplicate greeting locale", func(p *MessengerProfile) {
+			p.Greeting = append(p.Greeting, &Greeting{Locale: DefaultLocale, Text: "Hi"})
+		}, "greeting locale [default] is repeated"},
+		{"greeting text", func(p *MessengerProfile) { p.Greeting[0].Text = strings.Repeat("a", maxGreetingTextLength+1) },
+			"greeting[0].text must have up to 160 characters"},
+		{"menu without default locale", func(p *MessengerProfile) { p.PersistentMenu[0].Locale = "pt_BR" },
+			"persistent_menu requires the default locale"},
+		{"duplicate menu locale", func(p *MessengerProfile) {
+			p.PersistentMenu = append(p.PersistentMenu, &PersistentMenu{Locale: DefaultLocale, CallToActions: postbackMenuItems(1)})
+		}, "persistent_menu locale [default] is repeated"},
+		{"top level items", func(p *MessengerProfile) { p.PersistentMenu[0].CallToActions = postbackMenuItems(maxMenuItems + 1) },
+			"persistent_menu[0].call_to_actions: up to 3 items are allowed, got 4"},
+		{"nested items", func(p *MessengerProfile) {
+			p.PersistentMenu[0].CallToActions[2].CallToActions = postbackMenuItems(maxNestedMenuItems + 1)
+		}, "persistent_menu[0].call_to_actions[2].call_to_actions: up to 5 items are allowed, got 6"},
+		{"menu depth", func(p *MessengerProfile) { p.PersistentMenu[0].CallToActions[2] = nestedMenu(maxMenuDepth) },
+			"menus can be nested up to 3 levels"},
+		{"item title", func(p *MessengerProfile) {
+			p.PersistentMenu[0].CallToActions[0].Title = strings.Repeat("a", maxMenuItemTitleLength+1)
+		}, "persistent_menu[0].call_to_actions[0].title must have up to 30 characters"},
+		{"item type", func(p *MessengerProfile) { p.PersistentMenu[0].CallToActions[0].Type = "phone_number" },
+			"persistent_menu[0].call_to_actions[0] has invalid type [phone_number]"},
+		{"ice breakers", func(p *MessengerProfile) {
+			for len(p.IceBreakers) <= maxIceBreakers {
+				p.IceBreakers = append(p.IceBreakers, &IceBreaker{Question: "Why?", Payload: "WHY"})
+			}
+		}, "up to 4 ice_breakers are allowed, got 5"},
+		{"http domain", func(p *MessengerProfile) { p.WhitelistedDomains = append(p.WhitelistedDomains, "http://example.com") },
+			"whitelisted_domains[1] [http://example.com] must use https"},
+		{"nil greeting", func(p *MessengerProfile) { p.Greeting = append(p.Greeting, nil) },
+			"greeting[1] is nil"},
+	}
+	for _, tt := range tests {
+		profile := validProfile()
+		tt.change(profile)
+		err := profile.Validate()
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("%s: Validate() error = %v, want *ValidationError", tt.name, err)
+			continue
+		}
+		found := false
+		for _, violation := range validationErr.Violations {
+			if strings.Contains(violation, tt.want) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("%s: violations = %q, want %q", tt.name, validationErr.Violations, tt.want)
+		}
+	}
+}
+
+func TestMessengerProfileValidatePartial(t *testing.T) {
+	//Fields not set are not checked, so a profile can change a single field
+	profile := &MessengerProfile{WhitelistedDomains: []string{"https://example.com"}}
+	if err := profile.Validate(); err != nil {
+		t.Errorf("Validate() of a partial profile error = %v", err)
+	}
+}
@@ -1,5 +1,14 @@
 package fbmodelsend
 
+//QuickReplyContentTypeText is a quick reply with title and/or image that sends its payload back
+const QuickReplyContentTypeText = "text"
+
+//QuickReplyContentTypeUserPhoneNumber is a quick reply that sends back the phone number of the user profile
+const QuickReplyContentTypeUserPhoneNumber = "user_phone_number"
+
+//QuickReplyContentTypeUserEmail is a quick reply that sends back the email of the user profile
+const QuickReplyContentTypeUserEmail = "user_email"
+
 /*
 QuickReply - Represents a Facebook's quick reply items
 */
@@ -9,3 +18,32 @@ type QuickReply struct {
 	Payload     string `json:"payload,omitempty"`
 	ImageURL    string `json:"image_url,omitempty"`
 }
+
+/*
+NewTextQuickReply creates a text quick reply
+*/
+func NewTextQuickReply(title string, payload string) *QuickReply {
+	return &QuickReply{ContentType: QuickReplyContentTypeText, Title: title, Payload: payload}
+}
+
+/*
+NewImageQuickReply creates a text quick reply with an image (at least 24x24) beside title.
+title can be empty to show only the image.
+*/
+func NewImageQuickReply(title string, payload string, imageURL string) *QuickReply {
+	return &QuickReply{ContentType: QuickReplyContentTypeText, Title: title, Payload: payload, ImageURL: imageURL}
+}
+
+/*
+NewUserPhoneNumberQuickReply creates a quick reply that offers the phone number of the user profile
+*/
+func NewUserPhoneNumberQuickReply() *QuickReply {
+	return &QuickReply{ContentType: QuickReplyContentTypeUserPhoneNumber}
+}
+
+/*
+NewUserEmailQuickReply creates a quick reply that offers the email of the user profile
+*/
+func NewUserEmailQuickReply() *QuickReply {
+	return &QuickReply{ContentType: QuickReplyContentTypeUserEmail}
+}
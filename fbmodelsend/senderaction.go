@@ -1,11 +1,32 @@
 package fbmodelsend
 
+//SenderActionMarkSeen marks the last message sent by the user as read
+const SenderActionMarkSeen = "mark_seen"
+
+//SenderActionTypingOn shows the typing indicator to the user
+const SenderActionTypingOn = "typing_on"
+
+//SenderActionTypingOff hides the typing indicator from the user
+const SenderActionTypingOff = "typing_off"
+
 /*
 SenderAction is a struct that represents message states typing_on, typing_off, mark_seen
 More details at https://developers.facebook.com/docs/messenger-platform/send-api-reference
 */
 type SenderAction struct {
 	MessageType       string    `json:"message_type"`
+	PersonaID         string    `json:"persona_id,omitempty"`
 	Recipient         Recipient `json:"recipient"`
 	SenderActionState string    `json:"sender_action"`
 }
+
+/*
+IsValidSenderAction tells whether action is a sender action supported by Messenger
+*/
+func IsValidSenderAction(action string) bool {
+	switch action {
+	case SenderActionMarkSeen, SenderActionTypingOn, SenderActionTypingOff:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,13 @@
+package fbmodelsend
+
+/*
+SendResponse - Represents the Send API response to a message sent.
+MessageID is the same mid received later on delivery and read events.
+E.g.
+{"recipient_id":"1254477777772919","message_id":"m_AG5Hz2Uq7tuwNEhXfYYKj8mJEM_QPpz5jdCK48PnKAjSdjfipqxqMvK8ma6AC8fplwlqLP_5cgXIbu7I3rBN0P"}
+*/
+type SendResponse struct {
+	RecipientID  string `json:"recipient_id,omitempty"`
+	MessageID    string `json:"message_id,omitempty"`
+	AttachmentID string `json:"attachment_id,omitempty"`
+}
@@ -0,0 +1,168 @@
+package fbmodelsend
+
+//AirlineUpdateDelay is the update type of a delayed flight
+const AirlineUpdateDelay = "delay"
+
+//AirlineUpdateGateChange is the update type of a flight whose gate changed
+const AirlineUpdateGateChange = "gate_change"
+
+//AirlineUpdateCancellation is the update type of a cancelled flight
+const AirlineUpdateCancellation = "cancellation"
+
+/*
+AirlineBoardingPassPayload - Represents the payload of a Facebook Airline Boarding Pass Template Message
+More details at https://developers.facebook.com/docs/messenger-platform/reference/templates/airline-boarding-pass
+*/
+type AirlineBoardingPassPayload struct {
+	TemplateType string          `json:"template_type"`
+	IntroMessage string          `json:"intro_message"`
+	Locale       string          `json:"locale"`
+	ThemeColor   string          `json:"theme_color,omitempty"`
+	BoardingPass []*BoardingPass `json:"boarding_pass"`
+}
+
+/*
+BoardingPass - Boarding pass of one passenger for one flight.
+Either QRCode or BarcodeImageURL must be set.
+*/
+type BoardingPass struct {
+	PassengerName        string             `json:"passenger_name"`
+	PNRNumber            string             `json:"pnr_number"`
+	TravelClass          string             `json:"travel_class,omitempty"`
+	Seat                 string             `json:"seat,omitempty"`
+	AuxiliaryFields      []*AirlineField    `json:"auxiliary_fields,omitempty"`
+	SecondaryFields      []*AirlineField    `json:"secondary_fields,omitempty"`
+	LogoImageURL         string             `json:"logo_image_url"`
+	HeaderImageURL       string             `json:"header_image_url,omitempty"`
+	HeaderTextField      *AirlineField      `json:"header_text_field,omitempty"`
+	QRCode               string             `json:"qr_code,omitempty"`
+	BarcodeImageURL      string             `json:"barcode_image_url,omitempty"`
+	AboveBarcodeImageURL string             `json:"above_bar_code_image_url"`
+	FlightInfo           *AirlineFlightInfo `json:"flight_info"`
+}
+
+/*
+AirlineField - Label and value shown on a boarding pass
+*/
+type AirlineField struct {
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
+
+/*
+AirlineCheckinPayload - Represents the payload of a Facebook Airline Check-in Template Message
+More details at https://developers.facebook.com/docs/messenger-platform/reference/templates/airline-checkin
+*/
+type AirlineCheckinPayload struct {
+	TemplateType string               `json:"template_type"`
+	IntroMessage string               `json:"intro_message"`
+	Locale       string               `json:"locale"`
+	ThemeColor   string               `json:"theme_color,omitempty"`
+	PNRNumber    string               `json:"pnr_number,omitempty"`
+	CheckinURL   string               `json:"checkin_url"`
+	FlightInfo   []*AirlineFlightInfo `json:"flight_info"`
+}
+
+/*
+AirlineItineraryPayload - Represents the payload of a Facebook Airline Itinerary Template Message
+More details at https://developers.facebook.com/docs/messenger-platform/reference/templates/airline-itinerary
+*/
+type AirlineItineraryPayload struct {
+	TemplateType         string                         `json:"template_type"`
+	IntroMessage         string                         `json:"intro_message"`
+	Locale               string                         `json:"locale"`
+	ThemeColor           string                         `json:"theme_color,omitempty"`
+	PNRNumber            string                         `json:"pnr_number"`
+	PassengerInfo        []*AirlinePassengerInfo        `json:"passenger_info"`
+	FlightInfo           []*AirlineFlightInfo           `json:"flight_info"`
+	PassengerSegmentInfo []*AirlinePassengerSegmentInfo `json:"passenger_segment_info"`
+	PriceInfo            []*AirlinePriceInfo            `json:"price_info,omitempty"`
+	BasePrice            string                         `json:"base_price,omitempty"`
+	Tax                  string                         `json:"tax,omitempty"`
+	TotalPrice           string                         `json:"total_price"`
+	Currency             string                         `json:"currency"`
+}
+
+/*
+AirlinePassengerInfo - Passenger of an itinerary
+*/
+type AirlinePassengerInfo struct {
+	PassengerID  string `json:"passenger_id"`
+	TicketNumber string `json:"ticket_number,omitempty"`
+	Name         string `json:"name"`
+}
+
+/*
+AirlinePassengerSegmentInfo - Seat and products of a passenger in one flight segment
+*/
+type AirlinePassengerSegmentInfo struct {
+	SegmentID   string                `json:"segment_id"`
+	PassengerID string                `json:"passenger_id"`
+	Seat        string                `json:"seat"`
+	SeatType    string                `json:"seat_type"`
+	ProductInfo []*AirlineProductInfo `json:"product_info,omitempty"`
+}
+
+/*
+AirlineProductInfo - Product bought by a passenger for a flight segment, e.g. checked bags
+*/
+type AirlineProductInfo struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+/*
+AirlinePriceInfo - Additional cost of an itinerary
+*/
+type AirlinePriceInfo struct {
+	Title    string `json:"title"`
+	Amount   string `json:"amount"`
+	Currency string `json:"currency,omitempty"`
+}
+
+/*
+AirlineUpdatePayload - Represents the payload of a Facebook Airline Flight Update Template Message
+More details at https://developers.facebook.com/docs/messenger-platform/reference/templates/airline-flight-update
+*/
+type AirlineUpdatePayload struct {
+	TemplateType     string             `json:"template_type"`
+	IntroMessage     string             `json:"intro_message,omitempty"`
+	UpdateType       string             `json:"update_type"`
+	Locale           string             `json:"locale"`
+	ThemeColor       string             `json:"theme_color,omitempty"`
+	PNRNumber        string             `json:"pnr_number,omitempty"`
+	UpdateFlightInfo *AirlineFlightInfo `json:"update_flight_info"`
+}
+
+/*
+AirlineFlightInfo - Flight of airline templates. ConnectionID, SegmentID, AircraftType and TravelClass are used by itineraries.
+*/
+type AirlineFlightInfo struct {
+	ConnectionID     string                 `json:"connection_id,omitempty"`
+	SegmentID        string                 `json:"segment_id,omitempty"`
+	FlightNumber     string                 `json:"flight_number"`
+	AircraftType     string                 `json:"aircraft_type,omitempty"`
+	DepartureAirport *AirlineAirport        `json:"departure_airport,omitempty"`
+	ArrivalAirport   *AirlineAirport        `json:"arrival_airport,omitempty"`
+	FlightSchedule   *AirlineFlightSchedule `json:"flight_schedule,omitempty"`
+	TravelClass      string                 `json:"travel_class,omitempty"`
+}
+
+/*
+AirlineAirport - Departure or arrival airport of a flight
+*/
+type AirlineAirport struct {
+	AirportCode string `json:"airport_code"`
+	City        string `json:"city"`
+	Terminal    string `json:"terminal,omitempty"`
+	Gate        string `json:"gate,omitempty"`
+}
+
+/*
+AirlineFlightSchedule - Schedule of a flight. Times are in ISO 8601 format, e.g. 2016-01-02T19:05
+*/
+type AirlineFlightSchedule struct {
+	BoardingTime  string `json:"boarding_time,omitempty"`
+	DepartureTime string `json:"departure_time"`
+	ArrivalTime   string `json:"arrival_time,omitempty"`
+}
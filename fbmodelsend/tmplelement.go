@@ -1,14 +1,24 @@
 package fbmodelsend
 
+//MediaTypeImage is the media type of image elements in media templates
+const MediaTypeImage = "image"
+
+//MediaTypeVideo is the media type of video elements in media templates
+const MediaTypeVideo = "video"
+
 /*
-TemplateElement - Elements of Facebook Generic Template Message
+TemplateElement - Elements of Facebook Generic Template Message.
+Media Template elements use MediaType with either AttachmentID or URL (a Facebook URL of the image or video).
 */
 type TemplateElement struct {
-	Title    string    `json:"title,omitempty"`
-	ItemURL  string    `json:"item_url,omitempty"`
-	ImageURL string    `json:"image_url,omitempty"`
-	Subtitle string    `json:"subtitle,omitempty"`
-	Buttons  []*Button `json:"buttons,omitempty"`
+	Title        string    `json:"title,omitempty"`
+	ItemURL      string    `json:"item_url,omitempty"`
+	ImageURL     string    `json:"image_url,omitempty"`
+	Subtitle     string    `json:"subtitle,omitempty"`
+	MediaType    string    `json:"media_type,omitempty"`
+	AttachmentID string    `json:"attachment_id,omitempty"`
+	URL          string    `json:"url,omitempty"`
+	Buttons      []*Button `json:"buttons,omitempty"`
 }
 
 /*
@@ -0,0 +1,64 @@
+package fbmodelsend
+
+/*
+ReceiptPayload - Represents the payload of a Facebook Receipt Template Message.
+Timestamp is the order time in seconds since epoch, as a string.
+More details at https://developers.facebook.com/docs/messenger-platform/reference/templates/receipt
+*/
+type ReceiptPayload struct {
+	TemplateType  string               `json:"template_type"`
+	Sharable      bool                 `json:"sharable,omitempty"`
+	RecipientName string               `json:"recipient_name"`
+	MerchantName  string               `json:"merchant_name,omitempty"`
+	OrderNumber   string               `json:"order_number"`
+	Currency      string               `json:"currency"`
+	PaymentMethod string               `json:"payment_method"`
+	OrderURL      string               `json:"order_url,omitempty"`
+	Timestamp     string               `json:"timestamp,omitempty"`
+	Address       *ReceiptAddress      `json:"address,omitempty"`
+	Summary       ReceiptSummary       `json:"summary"`
+	Adjustments   []*ReceiptAdjustment `json:"adjustments,omitempty"`
+	Elements      []*ReceiptElement    `json:"elements,omitempty"`
+}
+
+/*
+ReceiptAddress - Shipping address of a receipt
+*/
+type ReceiptAddress struct {
+	Street1    string `json:"street_1"`
+	Street2    string `json:"street_2,omitempty"`
+	City       string `json:"city"`
+	PostalCode string `json:"postal_code"`
+	State      string `json:"state"`
+	Country    string `json:"country"`
+}
+
+/*
+ReceiptSummary - Payment summary of a receipt
+*/
+type ReceiptSummary struct {
+	Subtotal     float64 `json:"subtotal,omitempty"`
+	ShippingCost float64 `json:"shipping_cost,omitempty"`
+	TotalTax     float64 `json:"total_tax,omitempty"`
+	TotalCost    float64 `json:"total_cost"`
+}
+
+/*
+ReceiptAdjustment - Payment adjustment of a receipt, e.g. a discount
+*/
+type ReceiptAdjustment struct {
+	Name   string  `json:"name"`
+	Amount float64 `json:"amount"`
+}
+
+/*
+ReceiptElement - Item purchased in a receipt
+*/
+type ReceiptElement struct {
+	Title    string  `json:"title"`
+	Subtitle string  `json:"subtitle,omitempty"`
+	Quantity int     `json:"quantity,omitempty"`
+	Price    float64 `json:"price"`
+	Currency string  `json:"currency,omitempty"`
+	ImageURL string  `json:"image_url,omitempty"`
+}